@@ -8,14 +8,35 @@ import (
 )
 
 type Config struct {
-	CurrentUsername string   `json:"currentUsername"`
-	DBConfig        DBConfig `json:"database"`
+	CurrentUsername  string           `json:"currentUsername"`
+	DBConfig         DBConfig         `json:"database"`
+	AggregatorConfig AggregatorConfig `json:"aggregator"`
+	HTTPConfig       HTTPConfig       `json:"http"`
 }
 
 type DBConfig struct {
 	URL string `json:"url"`
 }
 
+type HTTPConfig struct {
+	ConnectTimeout          string   `json:"connectTimeout"`
+	ResponseHeaderTimeout   string   `json:"responseHeaderTimeout"`
+	RequestTimeout          string   `json:"requestTimeout"`
+	MaxBodySize             int64    `json:"maxBodySize"`
+	ProxyURL                string   `json:"proxyUrl"`
+	CABundle                string   `json:"caBundle"`
+	InsecureSkipVerifyHosts []string `json:"insecureSkipVerifyHosts"`
+}
+
+type AggregatorConfig struct {
+	KeepPostRevisions     bool   `json:"keepPostRevisions"`
+	Workers               int    `json:"workers"`
+	MaxConnectionsPerHost int    `json:"maxConnectionsPerHost"`
+	LeaseDuration         string `json:"leaseDuration"`
+	DisableAfterErrors    int    `json:"disableAfterErrors"`
+	FetchLogRetention     string `json:"fetchLogRetention"`
+}
+
 func NewConfig() (Config, error) {
 	path, err := configFilePath()
 	if err != nil {
@@ -19,18 +19,20 @@ WITH inserted_feed_follow AS (
     created_at,
     updated_at,
     user_id,
-    feed_id
+    feed_id,
+    category
   )
   VALUES (
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
   )
-  RETURNING id, created_at, updated_at, user_id, feed_id
+  RETURNING id, created_at, updated_at, user_id, feed_id, category
 )
-SELECT inserted_feed_follow.id, inserted_feed_follow.created_at, inserted_feed_follow.updated_at, inserted_feed_follow.user_id, inserted_feed_follow.feed_id, feeds.name AS feed_name, users.name AS user_name
+SELECT inserted_feed_follow.id, inserted_feed_follow.created_at, inserted_feed_follow.updated_at, inserted_feed_follow.user_id, inserted_feed_follow.feed_id, inserted_feed_follow.category, feeds.name AS feed_name, users.name AS user_name
 FROM inserted_feed_follow
 INNER JOIN users ON users.id = inserted_feed_follow.user_id
 INNER JOIN feeds ON feeds.id = inserted_feed_follow.feed_id
@@ -42,6 +44,7 @@ type CreateFeedFollowParams struct {
 	UpdatedAt time.Time
 	UserID    uuid.UUID
 	FeedID    uuid.UUID
+	Category  string
 }
 
 type CreateFeedFollowRow struct {
@@ -50,6 +53,7 @@ type CreateFeedFollowRow struct {
 	UpdatedAt time.Time
 	UserID    uuid.UUID
 	FeedID    uuid.UUID
+	Category  string
 	FeedName  string
 	UserName  string
 }
@@ -61,6 +65,7 @@ func (q *Queries) CreateFeedFollow(ctx context.Context, arg CreateFeedFollowPara
 		arg.UpdatedAt,
 		arg.UserID,
 		arg.FeedID,
+		arg.Category,
 	)
 	var i CreateFeedFollowRow
 	err := row.Scan(
@@ -69,6 +74,7 @@ func (q *Queries) CreateFeedFollow(ctx context.Context, arg CreateFeedFollowPara
 		&i.UpdatedAt,
 		&i.UserID,
 		&i.FeedID,
+		&i.Category,
 		&i.FeedName,
 		&i.UserName,
 	)
@@ -90,26 +96,41 @@ func (q *Queries) DeleteFeedFollow(ctx context.Context, arg DeleteFeedFollowPara
 	return err
 }
 
-const getFeedFollowsForUser = `-- name: GetFeedFollowsForUser :many
-SELECT feeds.name as feeds_name
+const getFollowedFeedsForUser = `-- name: GetFollowedFeedsForUser :many
+SELECT feeds.id, feeds.name, feeds.url, feeds.site_url, feed_follows.category
 FROM feed_follows
 INNER JOIN feeds ON feed_follows.feed_id = feeds.id
 WHERE feed_follows.user_id = $1
+ORDER BY feed_follows.category, feeds.name
 `
 
-func (q *Queries) GetFeedFollowsForUser(ctx context.Context, userID uuid.UUID) ([]string, error) {
-	rows, err := q.db.QueryContext(ctx, getFeedFollowsForUser, userID)
+type GetFollowedFeedsForUserRow struct {
+	ID       uuid.UUID
+	Name     string
+	Url      string
+	SiteUrl  string
+	Category string
+}
+
+func (q *Queries) GetFollowedFeedsForUser(ctx context.Context, userID uuid.UUID) ([]GetFollowedFeedsForUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFollowedFeedsForUser, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []GetFollowedFeedsForUserRow
 	for rows.Next() {
-		var feeds_name string
-		if err := rows.Scan(&feeds_name); err != nil {
+		var i GetFollowedFeedsForUserRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Url,
+			&i.SiteUrl,
+			&i.Category,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, feeds_name)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -119,3 +140,29 @@ func (q *Queries) GetFeedFollowsForUser(ctx context.Context, userID uuid.UUID) (
 	}
 	return items, nil
 }
+
+const moveFeedFollows = `-- name: MoveFeedFollows :exec
+INSERT INTO feed_follows (
+  id,
+  created_at,
+  updated_at,
+  user_id,
+  feed_id,
+  category
+)
+SELECT gen_random_uuid(), created_at, $1, user_id, $2, category
+  FROM feed_follows
+  WHERE feed_id = $3
+ON CONFLICT (user_id, feed_id) DO NOTHING
+`
+
+type MoveFeedFollowsParams struct {
+	UpdatedAt  time.Time
+	ToFeedID   uuid.UUID
+	FromFeedID uuid.UUID
+}
+
+func (q *Queries) MoveFeedFollows(ctx context.Context, arg MoveFeedFollowsParams) error {
+	_, err := q.db.ExecContext(ctx, moveFeedFollows, arg.UpdatedAt, arg.ToFeedID, arg.FromFeedID)
+	return err
+}
@@ -11,8 +11,60 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const claimNextFeedToFetch = `-- name: ClaimNextFeedToFetch :one
+UPDATE feeds
+  SET locked_by = $1, locked_until = now() + make_interval(secs => $2::double precision)
+  WHERE id = (
+    SELECT id
+      FROM feeds
+      WHERE disabled = FALSE
+        AND (next_fetch_at IS NULL OR next_fetch_at <= now())
+        AND (locked_until IS NULL OR locked_until < now())
+      ORDER BY next_fetch_at ASC NULLS FIRST
+      LIMIT 1
+      FOR UPDATE SKIP LOCKED
+  )
+  RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at, etag, last_modified, locked_by, locked_until, next_fetch_at, fetch_interval_seconds, consecutive_errors, last_error, last_error_at, disabled, site_url, ttl_seconds, update_period_seconds, skip_hours, skip_days
+`
+
+type ClaimNextFeedToFetchParams struct {
+	WorkerID     string
+	LeaseSeconds float64
+}
+
+func (q *Queries) ClaimNextFeedToFetch(ctx context.Context, arg ClaimNextFeedToFetchParams) (Feed, error) {
+	row := q.db.QueryRowContext(ctx, claimNextFeedToFetch, arg.WorkerID, arg.LeaseSeconds)
+	var i Feed
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Url,
+		&i.UserID,
+		&i.LastFetchedAt,
+		&i.Etag,
+		&i.LastModified,
+		&i.LockedBy,
+		&i.LockedUntil,
+		&i.NextFetchAt,
+		&i.FetchIntervalSeconds,
+		&i.ConsecutiveErrors,
+		&i.LastError,
+		&i.LastErrorAt,
+		&i.Disabled,
+		&i.SiteUrl,
+		&i.TtlSeconds,
+		&i.UpdatePeriodSeconds,
+		pq.Array(&i.SkipHours),
+		pq.Array(&i.SkipDays),
+	)
+	return i, err
+}
+
 const createFeed = `-- name: CreateFeed :one
 INSERT INTO feeds(
   id,
@@ -20,7 +72,8 @@ INSERT INTO feeds(
   updated_at,
   name,
   url,
-  user_id
+  user_id,
+  site_url
 )
 VALUES (
   $1,
@@ -28,9 +81,10 @@ VALUES (
   $3,
   $4,
   $5,
-  $6
+  $6,
+  $7
 )
-RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at
+RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at, etag, last_modified, locked_by, locked_until, next_fetch_at, fetch_interval_seconds, consecutive_errors, last_error, last_error_at, disabled, site_url, ttl_seconds, update_period_seconds, skip_hours, skip_days
 `
 
 type CreateFeedParams struct {
@@ -40,6 +94,7 @@ type CreateFeedParams struct {
 	Name      string
 	Url       string
 	UserID    uuid.UUID
+	SiteUrl   string
 }
 
 func (q *Queries) CreateFeed(ctx context.Context, arg CreateFeedParams) (Feed, error) {
@@ -50,6 +105,7 @@ func (q *Queries) CreateFeed(ctx context.Context, arg CreateFeedParams) (Feed, e
 		arg.Name,
 		arg.Url,
 		arg.UserID,
+		arg.SiteUrl,
 	)
 	var i Feed
 	err := row.Scan(
@@ -60,12 +116,53 @@ func (q *Queries) CreateFeed(ctx context.Context, arg CreateFeedParams) (Feed, e
 		&i.Url,
 		&i.UserID,
 		&i.LastFetchedAt,
+		&i.Etag,
+		&i.LastModified,
+		&i.LockedBy,
+		&i.LockedUntil,
+		&i.NextFetchAt,
+		&i.FetchIntervalSeconds,
+		&i.ConsecutiveErrors,
+		&i.LastError,
+		&i.LastErrorAt,
+		&i.Disabled,
+		&i.SiteUrl,
+		&i.TtlSeconds,
+		&i.UpdatePeriodSeconds,
+		pq.Array(&i.SkipHours),
+		pq.Array(&i.SkipDays),
 	)
 	return i, err
 }
 
+const deleteFeed = `-- name: DeleteFeed :exec
+DELETE FROM feeds
+  WHERE id = $1
+`
+
+func (q *Queries) DeleteFeed(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteFeed, id)
+	return err
+}
+
+const enableFeed = `-- name: EnableFeed :exec
+UPDATE feeds
+  SET disabled = FALSE, consecutive_errors = 0, next_fetch_at = NULL, updated_at = $2
+  WHERE id = $1
+`
+
+type EnableFeedParams struct {
+	ID        uuid.UUID
+	UpdatedAt time.Time
+}
+
+func (q *Queries) EnableFeed(ctx context.Context, arg EnableFeedParams) error {
+	_, err := q.db.ExecContext(ctx, enableFeed, arg.ID, arg.UpdatedAt)
+	return err
+}
+
 const getAllFeeds = `-- name: GetAllFeeds :many
-SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, etag, last_modified, locked_by, locked_until, next_fetch_at, fetch_interval_seconds, consecutive_errors, last_error, last_error_at, disabled, site_url, ttl_seconds, update_period_seconds, skip_hours, skip_days
   FROM feeds
 `
 
@@ -86,6 +183,74 @@ func (q *Queries) GetAllFeeds(ctx context.Context) ([]Feed, error) {
 			&i.Url,
 			&i.UserID,
 			&i.LastFetchedAt,
+			&i.Etag,
+			&i.LastModified,
+			&i.LockedBy,
+			&i.LockedUntil,
+			&i.NextFetchAt,
+			&i.FetchIntervalSeconds,
+			&i.ConsecutiveErrors,
+			&i.LastError,
+			&i.LastErrorAt,
+			&i.Disabled,
+			&i.SiteUrl,
+			&i.TtlSeconds,
+			&i.UpdatePeriodSeconds,
+			pq.Array(&i.SkipHours),
+			pq.Array(&i.SkipDays),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDisabledFeeds = `-- name: GetDisabledFeeds :many
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, etag, last_modified, locked_by, locked_until, next_fetch_at, fetch_interval_seconds, consecutive_errors, last_error, last_error_at, disabled, site_url, ttl_seconds, update_period_seconds, skip_hours, skip_days
+  FROM feeds
+  WHERE disabled = TRUE
+  ORDER BY last_error_at DESC
+`
+
+func (q *Queries) GetDisabledFeeds(ctx context.Context) ([]Feed, error) {
+	rows, err := q.db.QueryContext(ctx, getDisabledFeeds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Feed
+	for rows.Next() {
+		var i Feed
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Url,
+			&i.UserID,
+			&i.LastFetchedAt,
+			&i.Etag,
+			&i.LastModified,
+			&i.LockedBy,
+			&i.LockedUntil,
+			&i.NextFetchAt,
+			&i.FetchIntervalSeconds,
+			&i.ConsecutiveErrors,
+			&i.LastError,
+			&i.LastErrorAt,
+			&i.Disabled,
+			&i.SiteUrl,
+			&i.TtlSeconds,
+			&i.UpdatePeriodSeconds,
+			pq.Array(&i.SkipHours),
+			pq.Array(&i.SkipDays),
 		); err != nil {
 			return nil, err
 		}
@@ -101,7 +266,7 @@ func (q *Queries) GetAllFeeds(ctx context.Context) ([]Feed, error) {
 }
 
 const getFeedByUrl = `-- name: GetFeedByUrl :one
-SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, etag, last_modified, locked_by, locked_until, next_fetch_at, fetch_interval_seconds, consecutive_errors, last_error, last_error_at, disabled, site_url, ttl_seconds, update_period_seconds, skip_hours, skip_days
   FROM feeds
   WHERE url = $1
 `
@@ -117,44 +282,126 @@ func (q *Queries) GetFeedByUrl(ctx context.Context, url string) (Feed, error) {
 		&i.Url,
 		&i.UserID,
 		&i.LastFetchedAt,
+		&i.Etag,
+		&i.LastModified,
+		&i.LockedBy,
+		&i.LockedUntil,
+		&i.NextFetchAt,
+		&i.FetchIntervalSeconds,
+		&i.ConsecutiveErrors,
+		&i.LastError,
+		&i.LastErrorAt,
+		&i.Disabled,
+		&i.SiteUrl,
+		&i.TtlSeconds,
+		&i.UpdatePeriodSeconds,
+		pq.Array(&i.SkipHours),
+		pq.Array(&i.SkipDays),
 	)
 	return i, err
 }
 
-const getNextFeedToFetch = `-- name: GetNextFeedToFetch :one
-SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at
-  FROM feeds
-  ORDER BY last_fetched_at ASC NULLS FIRST LIMIT 1
+const markFeedFailed = `-- name: MarkFeedFailed :exec
+UPDATE feeds
+  SET updated_at = $2,
+    consecutive_errors = $3,
+    last_error = $4,
+    last_error_at = $5,
+    next_fetch_at = $6,
+    disabled = $7,
+    locked_by = '',
+    locked_until = NULL
+  WHERE id = $1
 `
 
-func (q *Queries) GetNextFeedToFetch(ctx context.Context) (Feed, error) {
-	row := q.db.QueryRowContext(ctx, getNextFeedToFetch)
-	var i Feed
-	err := row.Scan(
-		&i.ID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.Name,
-		&i.Url,
-		&i.UserID,
-		&i.LastFetchedAt,
+type MarkFeedFailedParams struct {
+	ID                uuid.UUID
+	UpdatedAt         time.Time
+	ConsecutiveErrors int32
+	LastError         string
+	LastErrorAt       sql.NullTime
+	NextFetchAt       sql.NullTime
+	Disabled          bool
+}
+
+func (q *Queries) MarkFeedFailed(ctx context.Context, arg MarkFeedFailedParams) error {
+	_, err := q.db.ExecContext(ctx, markFeedFailed,
+		arg.ID,
+		arg.UpdatedAt,
+		arg.ConsecutiveErrors,
+		arg.LastError,
+		arg.LastErrorAt,
+		arg.NextFetchAt,
+		arg.Disabled,
 	)
-	return i, err
+	return err
 }
 
 const markFeedFetched = `-- name: MarkFeedFetched :exec
 UPDATE feeds
-  SET last_fetched_at = $2, updated_at = $3
+  SET last_fetched_at = $2,
+    updated_at = $3,
+    etag = $4,
+    last_modified = $5,
+    next_fetch_at = $6,
+    fetch_interval_seconds = $7,
+    site_url = $8,
+    ttl_seconds = $9,
+    update_period_seconds = $10,
+    skip_hours = $11,
+    skip_days = $12,
+    consecutive_errors = 0,
+    locked_by = '',
+    locked_until = NULL
   WHERE id = $1
 `
 
 type MarkFeedFetchedParams struct {
-	ID            uuid.UUID
-	LastFetchedAt sql.NullTime
-	UpdatedAt     time.Time
+	ID                   uuid.UUID
+	LastFetchedAt        sql.NullTime
+	UpdatedAt            time.Time
+	Etag                 string
+	LastModified         string
+	NextFetchAt          sql.NullTime
+	FetchIntervalSeconds int32
+	SiteUrl              string
+	TtlSeconds           int32
+	UpdatePeriodSeconds  int32
+	SkipHours            []int32
+	SkipDays             []int32
 }
 
 func (q *Queries) MarkFeedFetched(ctx context.Context, arg MarkFeedFetchedParams) error {
-	_, err := q.db.ExecContext(ctx, markFeedFetched, arg.ID, arg.LastFetchedAt, arg.UpdatedAt)
+	_, err := q.db.ExecContext(ctx, markFeedFetched,
+		arg.ID,
+		arg.LastFetchedAt,
+		arg.UpdatedAt,
+		arg.Etag,
+		arg.LastModified,
+		arg.NextFetchAt,
+		arg.FetchIntervalSeconds,
+		arg.SiteUrl,
+		arg.TtlSeconds,
+		arg.UpdatePeriodSeconds,
+		pq.Array(arg.SkipHours),
+		pq.Array(arg.SkipDays),
+	)
+	return err
+}
+
+const updateFeedURL = `-- name: UpdateFeedURL :exec
+UPDATE feeds
+  SET url = $2, updated_at = $3
+  WHERE id = $1
+`
+
+type UpdateFeedURLParams struct {
+	ID        uuid.UUID
+	Url       string
+	UpdatedAt time.Time
+}
+
+func (q *Queries) UpdateFeedURL(ctx context.Context, arg UpdateFeedURLParams) error {
+	_, err := q.db.ExecContext(ctx, updateFeedURL, arg.ID, arg.Url, arg.UpdatedAt)
 	return err
 }
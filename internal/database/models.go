@@ -12,13 +12,28 @@ import (
 )
 
 type Feed struct {
-	ID            uuid.UUID
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	Name          string
-	Url           string
-	UserID        uuid.UUID
-	LastFetchedAt sql.NullTime
+	ID                   uuid.UUID
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Name                 string
+	Url                  string
+	UserID               uuid.UUID
+	LastFetchedAt        sql.NullTime
+	Etag                 string
+	LastModified         string
+	LockedBy             string
+	LockedUntil          sql.NullTime
+	NextFetchAt          sql.NullTime
+	FetchIntervalSeconds int32
+	ConsecutiveErrors    int32
+	LastError            string
+	LastErrorAt          sql.NullTime
+	Disabled             bool
+	SiteUrl              string
+	TtlSeconds           int32
+	UpdatePeriodSeconds  int32
+	SkipHours            []int32
+	SkipDays             []int32
 }
 
 type FeedFollow struct {
@@ -27,17 +42,59 @@ type FeedFollow struct {
 	UpdatedAt time.Time
 	UserID    uuid.UUID
 	FeedID    uuid.UUID
+	Category  string
+}
+
+type FetchLog struct {
+	ID           uuid.UUID
+	FeedID       uuid.UUID
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	HttpStatus   int32
+	Bytes        int64
+	ItemCount    int32
+	NewPostCount int32
+	Error        string
 }
 
 type Post struct {
+	ID                uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Title             string
+	Url               string
+	Description       string
+	PublishedAt       time.Time
+	FeedID            uuid.UUID
+	PublishedAtSource string
+	Guid              string
+	ContentHash       string
+	SearchVector      interface{}
+}
+
+type PostRead struct {
+	UserID uuid.UUID
+	PostID uuid.UUID
+	ReadAt time.Time
+}
+
+type PostRevision struct {
 	ID          uuid.UUID
 	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	PostID      uuid.UUID
 	Title       string
 	Url         string
 	Description string
-	PublishedAt time.Time
-	FeedID      uuid.UUID
+	ContentHash string
+}
+
+type PostStar struct {
+	UserID    uuid.UUID
+	PostID    uuid.UUID
+	Note      string
+	Tags      []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type User struct {
@@ -7,12 +7,13 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-const createPost = `-- name: CreatePost :one
+const createPost = `-- name: CreatePost :exec
 INSERT INTO posts (
   id,
   created_at,
@@ -21,7 +22,10 @@ INSERT INTO posts (
   url,
   description,
   published_at,
-  feed_id
+  feed_id,
+  published_at_source,
+  guid,
+  content_hash
 )
 VALUES (
   $1,
@@ -31,24 +35,29 @@ VALUES (
   $5,
   $6,
   $7,
-  $8
+  $8,
+  $9,
+  $10,
+  $11
 )
-RETURNING id, created_at, updated_at, title, url, description, published_at, feed_id
 `
 
 type CreatePostParams struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Url         string
-	Description string
-	PublishedAt time.Time
-	FeedID      uuid.UUID
+	ID                uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Title             string
+	Url               string
+	Description       string
+	PublishedAt       time.Time
+	FeedID            uuid.UUID
+	PublishedAtSource string
+	Guid              string
+	ContentHash       string
 }
 
-func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, error) {
-	row := q.db.QueryRowContext(ctx, createPost,
+func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) error {
+	_, err := q.db.ExecContext(ctx, createPost,
 		arg.ID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
@@ -57,8 +66,41 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		arg.Description,
 		arg.PublishedAt,
 		arg.FeedID,
+		arg.PublishedAtSource,
+		arg.Guid,
+		arg.ContentHash,
 	)
-	var i Post
+	return err
+}
+
+const getPostByGUID = `-- name: GetPostByGUID :one
+SELECT id, created_at, updated_at, title, url, description, published_at, feed_id, published_at_source, guid, content_hash
+  FROM posts
+  WHERE feed_id = $1 AND guid = $2
+`
+
+type GetPostByGUIDParams struct {
+	FeedID uuid.UUID
+	Guid   string
+}
+
+type GetPostByGUIDRow struct {
+	ID                uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Title             string
+	Url               string
+	Description       string
+	PublishedAt       time.Time
+	FeedID            uuid.UUID
+	PublishedAtSource string
+	Guid              string
+	ContentHash       string
+}
+
+func (q *Queries) GetPostByGUID(ctx context.Context, arg GetPostByGUIDParams) (GetPostByGUIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getPostByGUID, arg.FeedID, arg.Guid)
+	var i GetPostByGUIDRow
 	err := row.Scan(
 		&i.ID,
 		&i.CreatedAt,
@@ -68,35 +110,161 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		&i.Description,
 		&i.PublishedAt,
 		&i.FeedID,
+		&i.PublishedAtSource,
+		&i.Guid,
+		&i.ContentHash,
+	)
+	return i, err
+}
+
+const getPostByID = `-- name: GetPostByID :one
+SELECT id, created_at, updated_at, title, url, description, published_at, feed_id, published_at_source, guid, content_hash
+  FROM posts
+  WHERE id = $1
+`
+
+type GetPostByIDRow struct {
+	ID                uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Title             string
+	Url               string
+	Description       string
+	PublishedAt       time.Time
+	FeedID            uuid.UUID
+	PublishedAtSource string
+	Guid              string
+	ContentHash       string
+}
+
+func (q *Queries) GetPostByID(ctx context.Context, id uuid.UUID) (GetPostByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getPostByID, id)
+	var i GetPostByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Title,
+		&i.Url,
+		&i.Description,
+		&i.PublishedAt,
+		&i.FeedID,
+		&i.PublishedAtSource,
+		&i.Guid,
+		&i.ContentHash,
+	)
+	return i, err
+}
+
+const getPostByURL = `-- name: GetPostByURL :one
+SELECT id, created_at, updated_at, title, url, description, published_at, feed_id, published_at_source, guid, content_hash
+  FROM posts
+  WHERE feed_id = $1 AND url = $2 AND guid = url
+`
+
+type GetPostByURLParams struct {
+	FeedID uuid.UUID
+	Url    string
+}
+
+type GetPostByURLRow struct {
+	ID                uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Title             string
+	Url               string
+	Description       string
+	PublishedAt       time.Time
+	FeedID            uuid.UUID
+	PublishedAtSource string
+	Guid              string
+	ContentHash       string
+}
+
+func (q *Queries) GetPostByURL(ctx context.Context, arg GetPostByURLParams) (GetPostByURLRow, error) {
+	row := q.db.QueryRowContext(ctx, getPostByURL, arg.FeedID, arg.Url)
+	var i GetPostByURLRow
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Title,
+		&i.Url,
+		&i.Description,
+		&i.PublishedAt,
+		&i.FeedID,
+		&i.PublishedAtSource,
+		&i.Guid,
+		&i.ContentHash,
 	)
 	return i, err
 }
 
 const getPostsForUser = `-- name: GetPostsForUser :many
-SELECT title, url, published_at
+SELECT posts.id, posts.title, posts.url, posts.published_at, feeds.name AS feed_name, post_reads.read_at, post_stars.created_at AS starred_at
   FROM posts
-  WHERE feed_id IN (
-    SELECT feed_id
-      FROM feed_follows
-      WHERE user_id = $1
-  )
-  ORDER BY published_at DESC
-  LIMIT $2
+  INNER JOIN feeds ON feeds.id = posts.feed_id
+  INNER JOIN feed_follows ON feed_follows.feed_id = posts.feed_id AND feed_follows.user_id = $1
+  LEFT JOIN post_reads ON post_reads.post_id = posts.id AND post_reads.user_id = $1
+  LEFT JOIN post_stars ON post_stars.post_id = posts.id AND post_stars.user_id = $1
+  WHERE ($2::boolean OR post_reads.post_id IS NULL)
+    AND ($3::uuid IS NULL OR posts.feed_id = $3)
+    AND (
+      $4::text = ''
+      OR feed_follows.category = $4
+      OR feed_follows.category LIKE $4 || '/%'
+    )
+    AND ($5::timestamp IS NULL OR posts.published_at >= $5)
+    AND ($6::timestamp IS NULL OR posts.published_at < $6)
+    AND (
+      $7::timestamp IS NULL
+      OR ($8::boolean AND (posts.published_at, posts.id) > ($7, $9::uuid))
+      OR (NOT $8::boolean AND (posts.published_at, posts.id) < ($7, $9::uuid))
+    )
+  ORDER BY
+    CASE WHEN $8::boolean THEN posts.published_at END ASC,
+    CASE WHEN $8::boolean THEN posts.id END ASC,
+    posts.published_at DESC,
+    posts.id DESC
+  LIMIT $10
 `
 
 type GetPostsForUserParams struct {
-	UserID uuid.UUID
-	Limit  int32
+	UserID           uuid.UUID
+	IncludeRead      bool
+	FeedID           uuid.NullUUID
+	Category         string
+	Since            sql.NullTime
+	Until            sql.NullTime
+	AfterPublishedAt sql.NullTime
+	OldestFirst      bool
+	AfterID          uuid.NullUUID
+	MaxPosts         int32
 }
 
 type GetPostsForUserRow struct {
+	ID          uuid.UUID
 	Title       string
 	Url         string
 	PublishedAt time.Time
+	FeedName    string
+	ReadAt      sql.NullTime
+	StarredAt   sql.NullTime
 }
 
 func (q *Queries) GetPostsForUser(ctx context.Context, arg GetPostsForUserParams) ([]GetPostsForUserRow, error) {
-	rows, err := q.db.QueryContext(ctx, getPostsForUser, arg.UserID, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, getPostsForUser,
+		arg.UserID,
+		arg.IncludeRead,
+		arg.FeedID,
+		arg.Category,
+		arg.Since,
+		arg.Until,
+		arg.AfterPublishedAt,
+		arg.OldestFirst,
+		arg.AfterID,
+		arg.MaxPosts,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +272,106 @@ func (q *Queries) GetPostsForUser(ctx context.Context, arg GetPostsForUserParams
 	var items []GetPostsForUserRow
 	for rows.Next() {
 		var i GetPostsForUserRow
-		if err := rows.Scan(&i.Title, &i.Url, &i.PublishedAt); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Url,
+			&i.PublishedAt,
+			&i.FeedName,
+			&i.ReadAt,
+			&i.StarredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const movePosts = `-- name: MovePosts :exec
+UPDATE posts
+  SET feed_id = $1, updated_at = $2
+  WHERE feed_id = $3
+    AND guid NOT IN (
+      SELECT guid
+        FROM posts
+        WHERE feed_id = $1
+    )
+`
+
+type MovePostsParams struct {
+	ToFeedID   uuid.UUID
+	UpdatedAt  time.Time
+	FromFeedID uuid.UUID
+}
+
+func (q *Queries) MovePosts(ctx context.Context, arg MovePostsParams) error {
+	_, err := q.db.ExecContext(ctx, movePosts, arg.ToFeedID, arg.UpdatedAt, arg.FromFeedID)
+	return err
+}
+
+const searchPostsForUser = `-- name: SearchPostsForUser :many
+SELECT posts.id, posts.title, posts.url, posts.published_at, feeds.name AS feed_name,
+    ts_rank(posts.search_vector, query)::real AS rank,
+    ts_headline(
+      'english',
+      regexp_replace(posts.description, '<[^>]*>', ' ', 'g'),
+      query,
+      'StartSel=*, StopSel=*, MaxWords=35, MinWords=15, MaxFragments=2'
+    )::text AS snippet
+  FROM posts
+  INNER JOIN feeds ON feeds.id = posts.feed_id
+  CROSS JOIN websearch_to_tsquery('english', $1) AS query
+  WHERE posts.search_vector @@ query
+    AND posts.feed_id IN (
+      SELECT feed_id
+        FROM feed_follows
+        WHERE user_id = $2
+    )
+  ORDER BY rank DESC, posts.published_at DESC
+  LIMIT $3
+`
+
+type SearchPostsForUserParams struct {
+	Query      string
+	UserID     uuid.UUID
+	MaxResults int32
+}
+
+type SearchPostsForUserRow struct {
+	ID          uuid.UUID
+	Title       string
+	Url         string
+	PublishedAt time.Time
+	FeedName    string
+	Rank        float32
+	Snippet     string
+}
+
+func (q *Queries) SearchPostsForUser(ctx context.Context, arg SearchPostsForUserParams) ([]SearchPostsForUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchPostsForUser, arg.Query, arg.UserID, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchPostsForUserRow
+	for rows.Next() {
+		var i SearchPostsForUserRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Url,
+			&i.PublishedAt,
+			&i.FeedName,
+			&i.Rank,
+			&i.Snippet,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -117,3 +384,47 @@ func (q *Queries) GetPostsForUser(ctx context.Context, arg GetPostsForUserParams
 	}
 	return items, nil
 }
+
+const updatePost = `-- name: UpdatePost :exec
+UPDATE posts
+  SET updated_at = $2, title = $3, url = $4, description = $5, content_hash = $6
+  WHERE id = $1
+`
+
+type UpdatePostParams struct {
+	ID          uuid.UUID
+	UpdatedAt   time.Time
+	Title       string
+	Url         string
+	Description string
+	ContentHash string
+}
+
+func (q *Queries) UpdatePost(ctx context.Context, arg UpdatePostParams) error {
+	_, err := q.db.ExecContext(ctx, updatePost,
+		arg.ID,
+		arg.UpdatedAt,
+		arg.Title,
+		arg.Url,
+		arg.Description,
+		arg.ContentHash,
+	)
+	return err
+}
+
+const updatePostGUID = `-- name: UpdatePostGUID :exec
+UPDATE posts
+  SET updated_at = $2, guid = $3
+  WHERE id = $1
+`
+
+type UpdatePostGUIDParams struct {
+	ID        uuid.UUID
+	UpdatedAt time.Time
+	Guid      string
+}
+
+func (q *Queries) UpdatePostGUID(ctx context.Context, arg UpdatePostGUIDParams) error {
+	_, err := q.db.ExecContext(ctx, updatePostGUID, arg.ID, arg.UpdatedAt, arg.Guid)
+	return err
+}
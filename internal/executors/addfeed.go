@@ -3,25 +3,22 @@ package executors
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/rss"
 	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
 	"github.com/google/uuid"
 )
 
 func AddFeed(s *state.State, exe Executor, user database.User) error {
-	wantArgs := 2
-
-	if len(exe.Args) != wantArgs {
-		return fmt.Errorf(
-			"unexpected number of arguments: want %d, got %d",
-			wantArgs,
-			len(exe.Args),
-		)
-	}
+	name := exe.Args[0]
 
-	name, url := exe.Args[0], exe.Args[1]
+	url, err := discoverFeedURL(s, exe.Args[1])
+	if err != nil {
+		return err
+	}
 
 	timestamp := time.Now()
 
@@ -41,8 +38,6 @@ func AddFeed(s *state.State, exe Executor, user database.User) error {
 
 	fmt.Println("Successfully added the feed.")
 
-	fmt.Println("DEBUG:", feed)
-
 	createFeedFollowArgs := database.CreateFeedFollowParams{
 		ID:        uuid.New(),
 		CreatedAt: timestamp,
@@ -57,7 +52,36 @@ func AddFeed(s *state.State, exe Executor, user database.User) error {
 	}
 
 	fmt.Printf("You are now following the feed %q.\n", followRecord.FeedName)
-	fmt.Println("DEBUG:", followRecord)
 
 	return nil
 }
+
+// discoverFeedURL returns the URL of the feed published at the given
+// URL, which may be the feed itself or a web page that links to it. The
+// URL is used as it is if it cannot be fetched so that feeds can be
+// added while their servers are unavailable.
+func discoverFeedURL(s *state.State, url string) (string, error) {
+	candidates, err := rss.Discover(context.Background(), s.HTTPClient, url)
+	if err != nil {
+		fmt.Printf("WARNING: Unable to discover the feed at %s (%v), adding the URL as it is.\n", url, err)
+
+		return url, nil
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("no feeds were found at %s", url)
+	case 1:
+		if candidates[0].URL != url {
+			fmt.Printf("Found the feed at %s.\n", candidates[0].URL)
+		}
+
+		return candidates[0].URL, nil
+	default:
+		fmt.Printf("Multiple feeds were found at %s:\n\n", url)
+		printCandidates(os.Stdout, candidates)
+		fmt.Println()
+
+		return "", fmt.Errorf("found %d feeds at %s, please run addfeed again with one of the URLs above", len(candidates), url)
+	}
+}
@@ -2,21 +2,89 @@ package executors
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
+	"sync"
 	"time"
 
 	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/pubdate"
 	"codeflow.dananglin.me.uk/apollo/gator/internal/rss"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/schedule"
 	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
 	"github.com/google/uuid"
 )
 
-func Aggregate(s *state.State, exe Executor) error {
-	if len(exe.Args) != 1 {
-		return fmt.Errorf("unexpected number of arguments: want 1, got %d", len(exe.Args))
+const (
+	defaultWorkers               = 4
+	defaultMaxConnectionsPerHost = 2
+	defaultLeaseDuration         = 5 * time.Minute
+	defaultDisableAfterErrors    = 10
+	defaultFetchLogRetention     = 30 * 24 * time.Hour
+)
+
+// aggregator fetches the feeds that are due using a pool of workers.
+// Feeds are leased in the database before they are fetched so that any
+// number of aggregators can share the same database without fetching
+// the same feed. The interval is both the time between cycles and the
+// shortest time between fetches of a single feed.
+type aggregator struct {
+	state         *state.State
+	workerID      string
+	interval      time.Duration
+	leaseDuration time.Duration
+	workers       int
+	disableAfter  int
+	logRetention  time.Duration
+	limiter       *hostLimiter
+}
+
+// cycleSummary is the summary of the results of a single
+// aggregation cycle.
+type cycleSummary struct {
+	mu          sync.Mutex
+	fetched     int
+	notModified int
+	failed      int
+	newPosts    int
+}
+
+func (c *cycleSummary) addSuccess(result scrapeResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fetched++
+	c.newPosts += result.newPosts
+
+	if result.notModified {
+		c.notModified++
 	}
+}
+
+func (c *cycleSummary) addFailure() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failed++
+}
+
+// scrapeResult is the result of scraping a single feed. The HTTP
+// details are set whenever a response was received, even if the
+// scrape failed afterwards.
+type scrapeResult struct {
+	notModified  bool
+	statusCode   int
+	bytes        int
+	items        int
+	newPosts     int
+	permanentURL string
+}
 
+func Aggregate(s *state.State, exe Executor) error {
 	intervalArg := exe.Args[0]
 
 	interval, err := time.ParseDuration(intervalArg)
@@ -24,103 +92,644 @@ func Aggregate(s *state.State, exe Executor) error {
 		return fmt.Errorf("unable to parse the interval: %w", err)
 	}
 
-	fmt.Printf("Fetching feeds every %s\n", interval.String())
+	leaseDuration := defaultLeaseDuration
+
+	if s.Config.AggregatorConfig.LeaseDuration != "" {
+		leaseDuration, err = time.ParseDuration(s.Config.AggregatorConfig.LeaseDuration)
+		if err != nil {
+			return fmt.Errorf("unable to parse the lease duration: %w", err)
+		}
+	}
+
+	logRetention := defaultFetchLogRetention
+
+	if s.Config.AggregatorConfig.FetchLogRetention != "" {
+		logRetention, err = time.ParseDuration(s.Config.AggregatorConfig.FetchLogRetention)
+		if err != nil {
+			return fmt.Errorf("unable to parse the fetch log retention period: %w", err)
+		}
+	}
+
+	agg := aggregator{
+		state:         s,
+		workerID:      newWorkerID(),
+		interval:      interval,
+		leaseDuration: leaseDuration,
+		workers:       valueOrDefault(s.Config.AggregatorConfig.Workers, defaultWorkers),
+		disableAfter:  valueOrDefault(s.Config.AggregatorConfig.DisableAfterErrors, defaultDisableAfterErrors),
+		logRetention:  logRetention,
+		limiter: newHostLimiter(valueOrDefault(
+			s.Config.AggregatorConfig.MaxConnectionsPerHost,
+			defaultMaxConnectionsPerHost,
+		)),
+	}
+
+	fmt.Printf(
+		"Checking for feeds to fetch every %s with %d workers (worker ID: %s)\n",
+		interval.String(),
+		agg.workers,
+		agg.workerID,
+	)
 
 	tick := time.Tick(interval)
 
 	for range tick {
-		if err := scrapeFeeds(s); err != nil {
-			fmt.Println("ERROR: %v", err)
-		}
+		agg.runCycle()
 	}
 
 	return nil
 }
 
-func scrapeFeeds(s *state.State) error {
-	feed, err := s.DB.GetNextFeedToFetch(context.Background())
+// newWorkerID returns the identifier that this aggregator uses when
+// leasing feeds.
+func newWorkerID() string {
+	hostname, err := os.Hostname()
 	if err != nil {
-		return fmt.Errorf("unable to get the next feed from the database: %w", err)
+		hostname = "unknown"
+	}
+
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.NewString()[:8])
+}
+
+// runCycle fetches all the feeds that are due using the pool of
+// workers and prints a summary of the results when there are no more
+// feeds to fetch.
+func (a *aggregator) runCycle() {
+	start := time.Now()
+
+	var (
+		summary cycleSummary
+		wg      sync.WaitGroup
+	)
+
+	for range a.workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			a.work(&summary)
+		}()
+	}
+
+	wg.Wait()
+
+	fmt.Printf(
+		"Cycle complete in %s: %d fetched (%d not modified), %d failed, %d new posts\n",
+		time.Since(start).Round(time.Millisecond),
+		summary.fetched,
+		summary.notModified,
+		summary.failed,
+		summary.newPosts,
+	)
+
+	if _, err := a.state.DB.DeleteFetchLogBefore(context.Background(), start.Add(-a.logRetention)); err != nil {
+		fmt.Printf("ERROR: unable to prune the fetch log: %v\n", err)
+	}
+}
+
+// work claims and scrapes feeds until there are no more feeds due.
+func (a *aggregator) work(summary *cycleSummary) {
+	for {
+		feed, err := a.claimNextFeed()
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				fmt.Printf("ERROR: unable to claim the next feed to fetch: %v\n", err)
+			}
+
+			return
+		}
+
+		release := a.limiter.acquire(feed.Url)
+
+		startedAt := time.Now()
+		result, err := a.scrapeFeed(feed)
+		finishedAt := time.Now()
+
+		release()
+
+		if logErr := a.logFetch(feed, startedAt, finishedAt, result, err); logErr != nil {
+			fmt.Printf("ERROR: %v\n", logErr)
+		}
+
+		if err != nil {
+			fmt.Printf("ERROR: unable to scrape %q: %v\n", feed.Name, err)
+			summary.addFailure()
+
+			if err := a.markFeedFailed(feed, err); err != nil {
+				fmt.Printf("ERROR: %v\n", err)
+			}
+
+			continue
+		}
+
+		summary.addSuccess(result)
+
+		if result.permanentURL != "" && result.permanentURL != feed.Url {
+			if err := a.relocateFeed(feed, result.permanentURL); err != nil {
+				fmt.Printf("ERROR: unable to update the URL of %q: %v\n", feed.Name, err)
+			}
+		}
 	}
+}
 
-	fmt.Printf("\nFetching feed from %s\n", feed.Url)
+// relocateFeed updates the URL of a feed that has been permanently
+// redirected. If the new URL already belongs to another feed then the
+// posts and followers of this feed are merged into it and this feed is
+// removed. Each step can be safely repeated if the merge is interrupted.
+func (a *aggregator) relocateFeed(feed database.Feed, newURL string) error {
+	timestamp := time.Now()
 
-	feedDetails, err := rss.FetchFeed(context.Background(), feed.Url)
+	existing, err := a.state.DB.GetFeedByUrl(context.Background(), newURL)
 	if err != nil {
-		return fmt.Errorf("unable to fetch the feed: %w", err)
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("unable to check for an existing feed at %s: %w", newURL, err)
+		}
+
+		args := database.UpdateFeedURLParams{
+			ID:        feed.ID,
+			Url:       newURL,
+			UpdatedAt: timestamp,
+		}
+
+		if err := a.state.DB.UpdateFeedURL(context.Background(), args); err != nil {
+			return fmt.Errorf("unable to save the new URL: %w", err)
+		}
+
+		fmt.Printf("%q has permanently moved from %s to %s.\n", feed.Name, feed.Url, newURL)
+
+		return nil
+	}
+
+	moveFollowsArgs := database.MoveFeedFollowsParams{
+		UpdatedAt:  timestamp,
+		ToFeedID:   existing.ID,
+		FromFeedID: feed.ID,
+	}
+
+	if err := a.state.DB.MoveFeedFollows(context.Background(), moveFollowsArgs); err != nil {
+		return fmt.Errorf("unable to move the followers to %q: %w", existing.Name, err)
+	}
+
+	// Stars on posts that already exist in the other feed would be
+	// lost when this feed is removed so they are copied across first.
+	moveStarsArgs := database.MovePostStarsParams{
+		UpdatedAt:  timestamp,
+		FromFeedID: feed.ID,
+		ToFeedID:   existing.ID,
+	}
+
+	if err := a.state.DB.MovePostStars(context.Background(), moveStarsArgs); err != nil {
+		return fmt.Errorf("unable to move the stars to %q: %w", existing.Name, err)
+	}
+
+	movePostsArgs := database.MovePostsParams{
+		ToFeedID:   existing.ID,
+		UpdatedAt:  timestamp,
+		FromFeedID: feed.ID,
+	}
+
+	if err := a.state.DB.MovePosts(context.Background(), movePostsArgs); err != nil {
+		return fmt.Errorf("unable to move the posts to %q: %w", existing.Name, err)
+	}
+
+	if err := a.state.DB.DeleteFeed(context.Background(), feed.ID); err != nil {
+		return fmt.Errorf("unable to remove the old feed: %w", err)
 	}
 
+	fmt.Printf(
+		"%q has permanently moved from %s to %s and has been merged into %q.\n",
+		feed.Name,
+		feed.Url,
+		newURL,
+		existing.Name,
+	)
+
+	return nil
+}
+
+// logFetch records the fetch attempt in the fetch log.
+func (a *aggregator) logFetch(
+	feed database.Feed,
+	startedAt time.Time,
+	finishedAt time.Time,
+	result scrapeResult,
+	scrapeErr error,
+) error {
+	var errMessage string
+
+	if scrapeErr != nil {
+		errMessage = scrapeErr.Error()
+	}
+
+	args := database.CreateFetchLogParams{
+		ID:           uuid.New(),
+		FeedID:       feed.ID,
+		StartedAt:    startedAt,
+		FinishedAt:   finishedAt,
+		HttpStatus:   int32(result.statusCode),
+		Bytes:        int64(result.bytes),
+		ItemCount:    int32(result.items),
+		NewPostCount: int32(result.newPosts),
+		Error:        errMessage,
+	}
+
+	if err := a.state.DB.CreateFetchLog(context.Background(), args); err != nil {
+		return fmt.Errorf("unable to add the fetch of %q to the fetch log: %w", feed.Name, err)
+	}
+
+	return nil
+}
+
+// markFeedFailed records the failure in the database and schedules
+// a retry with exponential backoff. The feed is disabled once it has
+// failed too many times in a row.
+func (a *aggregator) markFeedFailed(feed database.Feed, scrapeErr error) error {
 	timestamp := time.Now()
+	failures := int(feed.ConsecutiveErrors) + 1
+	disabled := failures >= a.disableAfter
 
-	lastFetched := sql.NullTime{
-		Time:  timestamp,
-		Valid: true,
+	args := database.MarkFeedFailedParams{
+		ID:                feed.ID,
+		UpdatedAt:         timestamp,
+		ConsecutiveErrors: int32(failures),
+		LastError:         scrapeErr.Error(),
+		LastErrorAt: sql.NullTime{
+			Time:  timestamp,
+			Valid: true,
+		},
+		NextFetchAt: sql.NullTime{
+			Time:  timestamp.Add(schedule.Backoff(a.interval, failures)),
+			Valid: true,
+		},
+		Disabled: disabled,
 	}
 
-	markFeedFetchedArgs := database.MarkFeedFetchedParams{
-		ID:            feed.ID,
-		LastFetchedAt: lastFetched,
-		UpdatedAt:     timestamp,
+	if err := a.state.DB.MarkFeedFailed(context.Background(), args); err != nil {
+		return fmt.Errorf("unable to record the failure of %q in the database: %w", feed.Name, err)
 	}
 
-	if err := s.DB.MarkFeedFetched(context.Background(), markFeedFetchedArgs); err != nil {
-		return fmt.Errorf("unable to mark the feed as fetched in the database: %w", err)
+	if disabled {
+		fmt.Printf("%q has been disabled after %d consecutive failures.\n", feed.Name, failures)
 	}
 
-	timeParsingFormats := []string{
-		time.RFC1123Z,
-		time.RFC1123,
+	return nil
+}
+
+func (a *aggregator) claimNextFeed() (database.Feed, error) {
+	// The lease is measured against the clock of the database so that
+	// aggregators on hosts in other time zones or with skewed clocks
+	// agree on when it expires.
+	args := database.ClaimNextFeedToFetchParams{
+		WorkerID:     a.workerID,
+		LeaseSeconds: a.leaseDuration.Seconds(),
 	}
 
-	for _, item := range feedDetails.Channel.Items {
-		var (
-			pubDate time.Time
-			err     error
-		)
+	return a.state.DB.ClaimNextFeedToFetch(context.Background(), args)
+}
 
-		pubDateFormatted := false
+func (a *aggregator) scrapeFeed(feed database.Feed) (scrapeResult, error) {
+	fmt.Printf("Fetching feed from %s\n", feed.Url)
 
-		for _, format := range timeParsingFormats {
-			pubDate, err = time.Parse(format, item.PubDate)
-			if err == nil {
-				pubDateFormatted = true
+	validators := rss.CacheValidators{
+		ETag:         feed.Etag,
+		LastModified: feed.LastModified,
+	}
 
-				break
-			}
+	result, err := rss.FetchFeed(context.Background(), a.state.HTTPClient, feed.Url, validators)
+
+	scraped := scrapeResult{
+		statusCode:   result.StatusCode,
+		bytes:        result.Bytes,
+		permanentURL: result.PermanentURL,
+	}
+
+	if err != nil {
+		return scraped, fmt.Errorf("unable to fetch the feed: %w", err)
+	}
+
+	timestamp := time.Now()
+
+	currentInterval := time.Duration(feed.FetchIntervalSeconds) * time.Second
+
+	if result.NotModified {
+		// The hints of the last full fetch still apply to a feed that
+		// has not been modified.
+		if err := a.markFeedFetched(feed, result, timestamp, currentInterval, storedHints(feed)); err != nil {
+			return scraped, err
 		}
 
-		if !pubDateFormatted {
+		fmt.Printf("%q has not been modified since the last fetch.\n", feed.Name)
+
+		scraped.notModified = true
+
+		return scraped, nil
+	}
+
+	feedDetails := result.Feed
+
+	type datedItem struct {
+		item          rss.Item
+		pubDate       time.Time
+		pubDateSource pubdate.Source
+	}
+
+	items := make([]datedItem, len(feedDetails.Items))
+	pubDates := make([]time.Time, 0, len(feedDetails.Items))
+
+	for ind, item := range feedDetails.Items {
+		pubDate, pubDateSource := pubdate.Resolve(item.PubDate, feedDetails.LastBuildDate, timestamp)
+
+		if pubDateSource == pubdate.SourceItem {
+			pubDates = append(pubDates, pubDate)
+		} else {
 			fmt.Printf(
-				"Error: unable to format the publication date (%s) of %q.\n",
+				"Warning: unable to parse the publication date (%s) of %q; using the %s date instead.\n",
 				item.PubDate,
 				item.Title,
+				pubDateSource,
+			)
+		}
+
+		items[ind] = datedItem{
+			item:          item,
+			pubDate:       pubDate,
+			pubDateSource: pubDateSource,
+		}
+	}
+
+	scraped.items = len(items)
+	saveFailed := false
+
+	for _, dated := range items {
+		// Without a GUID or a link there is nothing that identifies
+		// the item from one fetch to the next.
+		if dated.item.GUID == "" && dated.item.Link == "" {
+			fmt.Printf("Warning: skipping %q as it has neither a GUID nor a link.\n", dated.item.Title)
+
+			continue
+		}
+
+		created, err := savePost(a.state, feed.ID, dated.item, dated.pubDate, dated.pubDateSource)
+		if err != nil {
+			fmt.Printf(
+				"Error: unable to save the post %q to the database: %v.\n",
+				dated.item.Title,
+				err,
 			)
 
+			saveFailed = true
+
 			continue
 		}
 
+		if created {
+			scraped.newPosts++
+		}
+	}
+
+	// The validators of the previous fetch are kept when a post could
+	// not be saved so that the next fetch downloads the feed again
+	// instead of receiving a 304 response and never retrying the post.
+	if saveFailed {
+		result.Validators = validators
+	}
+
+	interval := schedule.Interval(currentInterval, a.interval, feedDetails.Hints, pubDates)
+
+	if err := a.markFeedFetched(feed, result, timestamp, interval, feedDetails.Hints); err != nil {
+		return scraped, err
+	}
+
+	return scraped, nil
+}
+
+// markFeedFetched records the fetch of the feed in the database and
+// schedules the next fetch. Feeds without an interval of their own use
+// the interval of the aggregator.
+func (a *aggregator) markFeedFetched(
+	feed database.Feed,
+	result rss.FetchResult,
+	timestamp time.Time,
+	interval time.Duration,
+	hints rss.FetchHints,
+) error {
+	if interval <= 0 {
+		interval = a.interval
+	}
+
+	// The address of the website is kept if the feed has not been
+	// modified or does not link to it.
+	siteURL := feed.SiteUrl
+	if result.Feed != nil && result.Feed.Link != "" {
+		siteURL = result.Feed.Link
+	}
+
+	args := database.MarkFeedFetchedParams{
+		ID: feed.ID,
+		LastFetchedAt: sql.NullTime{
+			Time:  timestamp,
+			Valid: true,
+		},
+		UpdatedAt:    timestamp,
+		Etag:         result.Validators.ETag,
+		LastModified: result.Validators.LastModified,
+		NextFetchAt: sql.NullTime{
+			Time:  schedule.NextFetch(timestamp, interval, hints),
+			Valid: true,
+		},
+		FetchIntervalSeconds: int32(interval / time.Second),
+		SiteUrl:              siteURL,
+		TtlSeconds:           int32(hints.TTL / time.Second),
+		UpdatePeriodSeconds:  int32(hints.UpdatePeriod / time.Second),
+		SkipHours:            make([]int32, len(hints.SkipHours)),
+		SkipDays:             make([]int32, len(hints.SkipDays)),
+	}
+
+	for ind, hour := range hints.SkipHours {
+		args.SkipHours[ind] = int32(hour)
+	}
+
+	for ind, day := range hints.SkipDays {
+		args.SkipDays[ind] = int32(day)
+	}
+
+	if err := a.state.DB.MarkFeedFetched(context.Background(), args); err != nil {
+		return fmt.Errorf("unable to mark the feed as fetched in the database: %w", err)
+	}
+
+	return nil
+}
+
+// storedHints returns the fetch hints saved from the last time the
+// feed was downloaded.
+func storedHints(feed database.Feed) rss.FetchHints {
+	hints := rss.FetchHints{
+		TTL:          time.Duration(feed.TtlSeconds) * time.Second,
+		UpdatePeriod: time.Duration(feed.UpdatePeriodSeconds) * time.Second,
+		SkipHours:    make([]int, len(feed.SkipHours)),
+		SkipDays:     make([]time.Weekday, len(feed.SkipDays)),
+	}
+
+	for ind, hour := range feed.SkipHours {
+		hints.SkipHours[ind] = int(hour)
+	}
+
+	for ind, day := range feed.SkipDays {
+		hints.SkipDays[ind] = time.Weekday(day)
+	}
+
+	return hints
+}
+
+// savePost adds the item to the database as a new post. If the post
+// already exists and the content of the item has changed since it was
+// last saved then the post is updated, keeping the previous version as
+// a revision if enabled in the configuration. The returned boolean
+// reports whether a new post was created.
+func savePost(
+	s *state.State,
+	feedID uuid.UUID,
+	item rss.Item,
+	pubDate time.Time,
+	pubDateSource pubdate.Source,
+) (bool, error) {
+	// Posts are identified by the GUID that the feed assigns to
+	// the item, or by the link when the feed does not provide one.
+	guid := item.GUID
+	if guid == "" {
+		guid = item.Link
+	}
+
+	hash := contentHash(item)
+
+	getPostArgs := database.GetPostByGUIDParams{
+		FeedID: feedID,
+		Guid:   guid,
+	}
+
+	existing, err := s.DB.GetPostByGUID(context.Background(), getPostArgs)
+	if errors.Is(err, sql.ErrNoRows) && guid != item.Link && item.Link != "" {
+		existing, err = adoptLegacyPost(s, feedID, guid, item.Link)
+	}
+
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("unable to get the existing post: %w", err)
+		}
+
 		timestamp := time.Now()
 
 		args := database.CreatePostParams{
+			ID:                uuid.New(),
+			CreatedAt:         timestamp,
+			UpdatedAt:         timestamp,
+			Title:             item.Title,
+			Url:               item.Link,
+			Description:       item.Description,
+			FeedID:            feedID,
+			PublishedAt:       pubDate,
+			PublishedAtSource: string(pubDateSource),
+			Guid:              guid,
+			ContentHash:       hash,
+		}
+
+		if err := s.DB.CreatePost(context.Background(), args); err != nil {
+			// Another worker may have added the same post in the
+			// meantime.
+			if uniqueViolation(err) {
+				return false, nil
+			}
+
+			return false, fmt.Errorf("unable to add the post: %w", err)
+		}
+
+		return true, nil
+	}
+
+	if existing.ContentHash == hash {
+		return false, nil
+	}
+
+	timestamp := time.Now()
+
+	// Posts saved before content hashes were recorded have an empty
+	// hash. These are not treated as revisions.
+	if s.Config.AggregatorConfig.KeepPostRevisions && existing.ContentHash != "" {
+		revisionArgs := database.CreatePostRevisionParams{
 			ID:          uuid.New(),
 			CreatedAt:   timestamp,
-			UpdatedAt:   timestamp,
-			Title:       item.Title,
-			Url:         item.Link,
-			Description: item.Description,
-			FeedID:      feed.ID,
-			PublishedAt: pubDate,
+			PostID:      existing.ID,
+			Title:       existing.Title,
+			Url:         existing.Url,
+			Description: existing.Description,
+			ContentHash: existing.ContentHash,
 		}
 
-		_, err = s.DB.CreatePost(context.Background(), args)
-		if err != nil && !uniqueViolation(err) {
-			fmt.Printf(
-				"Error: unable to add the post %q to the database: %v.\n",
-				item.Title,
-				err,
-			)
+		if err := s.DB.CreatePostRevision(context.Background(), revisionArgs); err != nil {
+			return false, fmt.Errorf("unable to save the previous revision of the post: %w", err)
 		}
 	}
 
-	return nil
+	updateArgs := database.UpdatePostParams{
+		ID:          existing.ID,
+		UpdatedAt:   timestamp,
+		Title:       item.Title,
+		Url:         item.Link,
+		Description: item.Description,
+		ContentHash: hash,
+	}
+
+	if err := s.DB.UpdatePost(context.Background(), updateArgs); err != nil {
+		return false, fmt.Errorf("unable to update the post: %w", err)
+	}
+
+	if existing.ContentHash != "" {
+		fmt.Printf("Updated the post %q.\n", item.Title)
+	}
+
+	return false, nil
+}
+
+// adoptLegacyPost finds the post saved before posts were identified by
+// the GUID of the item. These posts have the link of the item as their
+// GUID. The GUID of the post is replaced with the GUID of the item so
+// that the post is found by its GUID from now on.
+func adoptLegacyPost(s *state.State, feedID uuid.UUID, guid, link string) (database.GetPostByGUIDRow, error) {
+	args := database.GetPostByURLParams{
+		FeedID: feedID,
+		Url:    link,
+	}
+
+	legacy, err := s.DB.GetPostByURL(context.Background(), args)
+	if err != nil {
+		return database.GetPostByGUIDRow{}, err
+	}
+
+	updateArgs := database.UpdatePostGUIDParams{
+		ID:        legacy.ID,
+		UpdatedAt: time.Now(),
+		Guid:      guid,
+	}
+
+	if err := s.DB.UpdatePostGUID(context.Background(), updateArgs); err != nil {
+		return database.GetPostByGUIDRow{}, fmt.Errorf("unable to update the GUID of the post: %w", err)
+	}
+
+	legacy.Guid = guid
+
+	return database.GetPostByGUIDRow(legacy), nil
+}
+
+// contentHash returns the SHA-256 hash of the content of the item that
+// is stored in the database.
+func contentHash(item rss.Item) string {
+	hash := sha256.New()
+
+	for _, field := range []string{item.Title, item.Link, item.Description} {
+		hash.Write([]byte(field))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
 }
@@ -2,49 +2,237 @@ package executors
 
 import (
 	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
+	"time"
 
 	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/pubdate"
 	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+	"github.com/google/uuid"
 )
 
+const (
+	sortNewest = "newest"
+	sortOldest = "oldest"
+)
+
+// postRecord is a post in the output of the browse command. Cursor
+// can be passed to --after to continue after the post. ReadAt is null
+// if the post has not been read.
+type postRecord struct {
+	ID          uuid.UUID  `json:"id"`
+	Title       string     `json:"title"`
+	Feed        string     `json:"feed"`
+	URL         string     `json:"url"`
+	PublishedAt time.Time  `json:"published_at"`
+	ReadAt      *time.Time `json:"read_at"`
+	Cursor      string     `json:"cursor"`
+}
+
 func Browse(s *state.State, exe Executor, user database.User) error {
-	if len(exe.Args) > 1 {
-		return fmt.Errorf("unexpected number of arguments: want 0 or 1, got %d", len(exe.Args))
+	args, err := parseBrowseArgs(s, exe, user)
+	if err != nil {
+		return err
+	}
+
+	posts, err := s.DB.GetPostsForUser(context.Background(), args)
+	if err != nil {
+		return fmt.Errorf("unable to get the posts: %w", err)
+	}
+
+	records := make([]postRecord, len(posts))
+
+	for ind, post := range posts {
+		records[ind] = postRecord{
+			ID:          post.ID,
+			Title:       post.Title,
+			Feed:        post.FeedName,
+			URL:         post.Url,
+			PublishedAt: post.PublishedAt,
+			ReadAt:      nullTimePointer(post.ReadAt),
+			Cursor:      encodeCursor(post.PublishedAt, post.ID),
+		}
+	}
+
+	return render(exe, records, func(writer io.Writer) error {
+		if len(records) == 0 {
+			if args.IncludeRead {
+				fmt.Fprintln(writer, "There are no posts.")
+			} else {
+				fmt.Fprintln(writer, "There are no unread posts.")
+			}
+
+			return nil
+		}
+
+		fmt.Fprintf(writer, "\nPosts:\n\n")
+
+		for _, post := range records {
+			fmt.Fprintf(
+				writer,
+				"- ID: %s\n  Title: %s\n  Feed: %s\n  URL: %s\n  Published at: %s\n",
+				post.ID,
+				post.Title,
+				post.Feed,
+				post.URL,
+				post.PublishedAt,
+			)
+
+			if post.ReadAt != nil {
+				fmt.Fprintf(writer, "  Read at: %s\n", *post.ReadAt)
+			}
+		}
+
+		// A full page means that there may be more posts so the
+		// cursor of the last post is printed for fetching the next
+		// page.
+		if len(records) == int(args.MaxPosts) {
+			fmt.Fprintf(writer, "\nNext page: --after %s\n", records[len(records)-1].Cursor)
+		}
+
+		return nil
+	})
+}
+
+// parseBrowseArgs converts the arguments of the browse command into
+// the parameters of the query. The page size can be given either as the
+// limit argument or with --page.
+func parseBrowseArgs(s *state.State, exe Executor, user database.User) (database.GetPostsForUserParams, error) {
+	args := database.GetPostsForUserParams{
+		UserID:      user.ID,
+		IncludeRead: exe.HasFlag("all") && !exe.HasFlag("unread"),
+		Category:    exe.Flag("category"),
+		MaxPosts:    2,
 	}
 
-	var err error
+	if exe.HasFlag("feed") {
+		feed, err := s.DB.GetFeedByUrl(context.Background(), exe.Flag("feed"))
+		if err != nil {
+			return args, fmt.Errorf("unable to get the feed data from the database: %w", err)
+		}
+
+		args.FeedID = uuid.NullUUID{UUID: feed.ID, Valid: true}
+	}
+
+	now := time.Now()
+
+	for _, name := range []string{"since", "until"} {
+		if !exe.HasFlag(name) {
+			continue
+		}
+
+		bound, err := parseTimeBound(exe.Flag(name), now)
+		if err != nil {
+			return args, fmt.Errorf("unable to parse the value of --%s: %w", name, err)
+		}
 
-	limit := 2
+		value := sql.NullTime{Time: bound.UTC(), Valid: true}
+
+		if name == "since" {
+			args.Since = value
+		} else {
+			args.Until = value
+		}
+	}
+
+	switch exe.Flag("sort") {
+	case "", sortNewest:
+		args.OldestFirst = false
+	case sortOldest:
+		args.OldestFirst = true
+	default:
+		return args, fmt.Errorf("unrecognised sort order %q: want %s or %s", exe.Flag("sort"), sortNewest, sortOldest)
+	}
+
+	if exe.HasFlag("after") {
+		publishedAt, postID, err := decodeCursor(exe.Flag("after"))
+		if err != nil {
+			return args, err
+		}
+
+		args.AfterPublishedAt = sql.NullTime{Time: publishedAt, Valid: true}
+		args.AfterID = uuid.NullUUID{UUID: postID, Valid: true}
+	}
+
+	pageSize := exe.Flag("page")
 
 	if len(exe.Args) == 1 {
-		limit, err = strconv.Atoi(exe.Args[0])
+		if pageSize != "" {
+			return args, errors.New("the limit argument and --page cannot be used together")
+		}
+
+		pageSize = exe.Args[0]
+	}
+
+	if pageSize != "" {
+		limit, err := strconv.Atoi(pageSize)
 		if err != nil {
-			return fmt.Errorf("unable to convert %s to a number: %w", exe.Args[0], err)
+			return args, fmt.Errorf("unable to convert %s to a number: %w", pageSize, err)
 		}
+
+		args.MaxPosts = int32(limit)
 	}
 
-	args := database.GetPostsForUserParams{
-		UserID: user.ID,
-		Limit:  int32(limit),
+	if args.MaxPosts <= 0 {
+		return args, errors.New("the number of posts must be greater than zero")
 	}
 
-	posts, err := s.DB.GetPostsForUser(context.Background(), args)
+	return args, nil
+}
+
+// parseTimeBound parses the value of --since or --until. The value is
+// either a date or a duration such as "36h" or "7d" which is counted
+// back from now.
+func parseTimeBound(value string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if count, err := strconv.Atoi(days); err == nil {
+			return now.AddDate(0, 0, -count), nil
+		}
+	}
+
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+
+	return pubdate.Parse(value)
+}
+
+// encodeCursor returns the cursor pointing at the post with the given
+// publication date and ID. The cursor is opaque to the user.
+func encodeCursor(publishedAt time.Time, postID uuid.UUID) string {
+	value := publishedAt.UTC().Format(time.RFC3339Nano) + "|" + postID.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func decodeCursor(cursor string) (time.Time, uuid.UUID, error) {
+	errInvalidCursor := fmt.Errorf("invalid cursor: %q", cursor)
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
 	if err != nil {
-		return fmt.Errorf("unable to get the posts: %w", err)
+		return time.Time{}, uuid.Nil, errInvalidCursor
 	}
 
-	fmt.Printf("\nPosts:\n\n")
+	timestamp, id, ok := strings.Cut(string(data), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, errInvalidCursor
+	}
 
-	for _, post := range posts {
-		fmt.Printf(
-			"- Title: %s\n  URL: %s\n  Published at: %s\n",
-			post.Title,
-			post.Url,
-			post.PublishedAt,
-		)
+	publishedAt, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return time.Time{}, uuid.Nil, errInvalidCursor
+	}
+
+	postID, err := uuid.Parse(id)
+	if err != nil {
+		return time.Time{}, uuid.Nil, errInvalidCursor
 	}
 
-	return nil
+	return publishedAt, postID, nil
 }
@@ -0,0 +1,234 @@
+package executors
+
+// Commands returns the declarations of all the commands in the order in
+// which they are listed in the help.
+func Commands() []Command {
+	postSelectionFlags := func(state string) []Flag {
+		return []Flag{
+			{Name: "all", Description: "Mark every post from the feeds that you follow as " + state + "."},
+			{Name: "feed", Value: "url", Description: "Mark every post from the feed as " + state + "."},
+		}
+	}
+
+	return []Command{
+		{
+			Name:    "register",
+			Summary: "Register a new user and log in as that user.",
+			Arguments: []Argument{
+				{Name: "username", Description: "The name of the new user."},
+			},
+			Run: Register,
+		},
+		{
+			Name:    "login",
+			Summary: "Log in as a registered user.",
+			Arguments: []Argument{
+				{Name: "username", Description: "The name of the user."},
+			},
+			Run: Login,
+		},
+		{
+			Name:    "users",
+			Summary: "List the registered users.",
+			Run:     Users,
+		},
+		{
+			Name:    "reset",
+			Summary: "Remove all users and their data from the database.",
+			Run:     Reset,
+		},
+		{
+			Name:    "aggregate",
+			Summary: "Fetch the feeds continuously and save their posts.",
+			Arguments: []Argument{
+				{Name: "interval", Description: "The time between aggregation cycles, e.g. 1m or 30s."},
+			},
+			Run: Aggregate,
+		},
+		{
+			Name:        "addfeed",
+			Summary:     "Add a feed and follow it.",
+			Description: "The URL may be the address of the feed or of a web page that links to the feed.",
+			Arguments: []Argument{
+				{Name: "name", Description: "The name of the feed."},
+				{Name: "url", Description: "The URL of the feed or of the website."},
+			},
+			Run: MiddlewareLoggedIn(AddFeed),
+		},
+		{
+			Name:    "discover",
+			Summary: "List the feeds published by a website.",
+			Arguments: []Argument{
+				{Name: "url", Description: "The URL of the website."},
+			},
+			Run: Discover,
+		},
+		{
+			Name:    "feeds",
+			Summary: "List all the feeds.",
+			Run:     Feeds,
+		},
+		{
+			Name:    "import-opml",
+			Summary: "Follow the feeds listed in an OPML file.",
+			Arguments: []Argument{
+				{Name: "file", Description: "The path to the OPML file."},
+			},
+			Run: MiddlewareLoggedIn(ImportOPML),
+		},
+		{
+			Name:    "export-opml",
+			Summary: "Export the feeds that you follow as an OPML file.",
+			Arguments: []Argument{
+				{Name: "file", Description: "The path to the OPML file. The OPML is printed if not set.", Optional: true},
+			},
+			Run: MiddlewareLoggedIn(ExportOPML),
+		},
+		{
+			Name:    "follow",
+			Summary: "Follow a feed.",
+			Arguments: []Argument{
+				{Name: "url", Description: "The URL of the feed."},
+			},
+			Run: MiddlewareLoggedIn(Follow),
+		},
+		{
+			Name:    "unfollow",
+			Summary: "Unfollow a feed.",
+			Arguments: []Argument{
+				{Name: "url", Description: "The URL of the feed."},
+			},
+			Run: MiddlewareLoggedIn(Unfollow),
+		},
+		{
+			Name:    "following",
+			Summary: "List the feeds that you follow.",
+			Run:     MiddlewareLoggedIn(Following),
+		},
+		{
+			Name:    "browse",
+			Summary: "Show the latest unread posts from the feeds that you follow.",
+			Description: "When a full page of posts is shown the cursor of the next page is printed " +
+				"and can be passed to --after to continue from where the page ended.",
+			Arguments: []Argument{
+				{Name: "limit", Description: "The number of posts to show (default 2).", Optional: true},
+			},
+			Flags: []Flag{
+				{Name: "all", Description: "Include the posts that you have already read."},
+				{Name: "unread", Description: "Only show the posts that you have not read (default)."},
+				{Name: "feed", Value: "url", Description: "Only show the posts from the feed."},
+				{Name: "category", Value: "name", Description: "Only show the posts from the feeds in the category."},
+				{Name: "since", Value: "time", Description: "Only show the posts published since the date or duration, e.g. 2024-06-01 or 7d."},
+				{Name: "until", Value: "time", Description: "Only show the posts published before the date or duration."},
+				{Name: "sort", Value: "order", Description: "Sort the posts by newest or oldest first (default newest)."},
+				{Name: "page", Value: "size", Description: "The number of posts to show, as an alternative to the limit argument."},
+				{Name: "after", Value: "cursor", Description: "Show the posts after the cursor printed by a previous page."},
+			},
+			Run: MiddlewareLoggedIn(Browse),
+		},
+		{
+			Name:    "tui",
+			Summary: "Read the posts in a full screen terminal interface.",
+			Description: "The sidebar lists the categories and feeds that you follow and the posts from the " +
+				"selected entry are listed above a preview of the selected post. The keys are shown at the bottom of the screen.",
+			Run: MiddlewareLoggedIn(TUI),
+		},
+		{
+			Name:    "read",
+			Summary: "Mark posts as read.",
+			Arguments: []Argument{
+				{Name: "post-id", Description: "The ID of the post.", Optional: true},
+			},
+			Flags: postSelectionFlags("read"),
+			Run:   MiddlewareLoggedIn(Read),
+		},
+		{
+			Name:    "unread",
+			Summary: "Mark posts as unread.",
+			Arguments: []Argument{
+				{Name: "post-id", Description: "The ID of the post.", Optional: true},
+			},
+			Flags: postSelectionFlags("unread"),
+			Run:   MiddlewareLoggedIn(Unread),
+		},
+		{
+			Name:        "star",
+			Summary:     "Star a post to keep it for later.",
+			Description: "Starring a post again replaces its note and tags.",
+			Arguments: []Argument{
+				{Name: "post-id", Description: "The ID of the post."},
+			},
+			Flags: []Flag{
+				{Name: "note", Value: "text", Description: "A note about the post."},
+				{Name: "tags", Value: "tags", Description: "A comma separated list of tags."},
+			},
+			Run: MiddlewareLoggedIn(Star),
+		},
+		{
+			Name:    "unstar",
+			Summary: "Remove the star from a post.",
+			Arguments: []Argument{
+				{Name: "post-id", Description: "The ID of the post."},
+			},
+			Run: MiddlewareLoggedIn(Unstar),
+		},
+		{
+			Name:    "starred",
+			Summary: "List the posts that you have starred.",
+			Flags: []Flag{
+				{Name: "tag", Value: "tag", Description: "Only list the posts with the tag."},
+			},
+			Run: MiddlewareLoggedIn(Starred),
+		},
+		{
+			Name:        "search",
+			Summary:     "Search the posts from the feeds that you follow.",
+			Description: "The query supports quoted phrases, OR and a leading - to exclude a word.",
+			Arguments: []Argument{
+				{Name: "query", Description: "The words to search for.", Variadic: true},
+			},
+			Flags: []Flag{
+				{Name: "limit", Value: "n", Description: "The maximum number of results (default 10)."},
+			},
+			Run: MiddlewareLoggedIn(Search),
+		},
+		{
+			Name:    "revisions",
+			Summary: "Show the previous revisions of a post.",
+			Arguments: []Argument{
+				{Name: "post-id", Description: "The ID of the post."},
+			},
+			Run: Revisions,
+		},
+		{
+			Name:    "disabled-feeds",
+			Summary: "List the feeds that were disabled after repeated errors.",
+			Run:     DisabledFeeds,
+		},
+		{
+			Name:    "enable-feed",
+			Summary: "Enable a disabled feed.",
+			Arguments: []Argument{
+				{Name: "url", Description: "The URL of the feed."},
+			},
+			Run: EnableFeed,
+		},
+		{
+			Name:    "feed-log",
+			Summary: "Show the latest fetches of a feed.",
+			Arguments: []Argument{
+				{Name: "url", Description: "The URL of the feed."},
+				{Name: "limit", Description: "The number of fetches to show (default 10).", Optional: true},
+			},
+			Run: FeedLog,
+		},
+		{
+			Name:    "prune-feed-log",
+			Summary: "Remove old entries from the fetch log.",
+			Arguments: []Argument{
+				{Name: "max-age", Description: "The age of the oldest entry to keep, e.g. 720h."},
+			},
+			Run: PruneFeedLog,
+		},
+	}
+}
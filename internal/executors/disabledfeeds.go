@@ -0,0 +1,63 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+)
+
+// disabledFeedRecord is a feed in the output of the disabled-feeds
+// command.
+type disabledFeedRecord struct {
+	Name              string     `json:"name"`
+	URL               string     `json:"url"`
+	ConsecutiveErrors int32      `json:"consecutive_errors"`
+	LastErrorAt       *time.Time `json:"last_error_at"`
+	LastError         string     `json:"last_error"`
+}
+
+func DisabledFeeds(s *state.State, exe Executor) error {
+	feeds, err := s.DB.GetDisabledFeeds(context.Background())
+	if err != nil {
+		return fmt.Errorf("unable to get the disabled feeds from the database: %w", err)
+	}
+
+	records := make([]disabledFeedRecord, len(feeds))
+
+	for ind, feed := range feeds {
+		records[ind] = disabledFeedRecord{
+			Name:              feed.Name,
+			URL:               feed.Url,
+			ConsecutiveErrors: feed.ConsecutiveErrors,
+			LastErrorAt:       nullTimePointer(feed.LastErrorAt),
+			LastError:         feed.LastError,
+		}
+	}
+
+	return render(exe, records, func(writer io.Writer) error {
+		if len(records) == 0 {
+			fmt.Fprintln(writer, "There are no disabled feeds.")
+
+			return nil
+		}
+
+		fmt.Fprintf(writer, "Disabled feeds:\n\n")
+
+		for ind, feed := range records {
+			fmt.Fprintf(
+				writer,
+				"- Name: %s\n  URL: %s\n  Consecutive errors: %d\n  Last error at: %s\n  Last error: %s\n",
+				feed.Name,
+				feed.URL,
+				feed.ConsecutiveErrors,
+				feeds[ind].LastErrorAt.Time,
+				feed.LastError,
+			)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/rss"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+)
+
+// candidateRecord is a feed in the output of the discover command.
+// Type is the media type of the feed and may be empty.
+type candidateRecord struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+	Type  string `json:"type"`
+}
+
+func Discover(s *state.State, exe Executor) error {
+	url := exe.Args[0]
+
+	candidates, err := rss.Discover(context.Background(), s.HTTPClient, url)
+	if err != nil {
+		return fmt.Errorf("unable to discover the feeds at %s: %w", url, err)
+	}
+
+	records := make([]candidateRecord, len(candidates))
+
+	for ind, candidate := range candidates {
+		records[ind] = candidateRecord{
+			URL:   candidate.URL,
+			Title: candidate.Title,
+			Type:  candidate.Type,
+		}
+	}
+
+	return render(exe, records, func(writer io.Writer) error {
+		if len(candidates) == 0 {
+			fmt.Fprintf(writer, "No feeds were found at %s.\n", url)
+
+			return nil
+		}
+
+		fmt.Fprintf(writer, "Feeds found at %s:\n\n", url)
+		printCandidates(writer, candidates)
+
+		return nil
+	})
+}
+
+func printCandidates(writer io.Writer, candidates []rss.Candidate) {
+	for _, candidate := range candidates {
+		title := candidate.Title
+		if title == "" {
+			title = "(untitled)"
+		}
+
+		fmt.Fprintf(
+			writer,
+			"- Title: %s\n  URL: %s\n  Type: %s\n",
+			title,
+			candidate.URL,
+			candidate.Type,
+		)
+	}
+}
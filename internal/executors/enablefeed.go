@@ -0,0 +1,32 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+)
+
+func EnableFeed(s *state.State, exe Executor) error {
+	url := exe.Args[0]
+
+	feed, err := s.DB.GetFeedByUrl(context.Background(), url)
+	if err != nil {
+		return fmt.Errorf("unable to get the feed data from the database: %w", err)
+	}
+
+	args := database.EnableFeedParams{
+		ID:        feed.ID,
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.DB.EnableFeed(context.Background(), args); err != nil {
+		return fmt.Errorf("unable to enable the feed: %w", err)
+	}
+
+	fmt.Printf("%q is enabled and will be fetched on the next aggregation cycle.\n", feed.Name)
+
+	return nil
+}
@@ -2,30 +2,381 @@ package executors
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
 
+	"codeflow.dananglin.me.uk/apollo/gator/internal/output"
 	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
 )
 
+const (
+	helpFlag   = "help"
+	outputFlag = "output"
+)
+
+// globalFlags are the flags accepted by every command.
+var globalFlags = []Flag{
+	{
+		Name:        outputFlag,
+		Value:       "format",
+		Description: "The format of the listings: text, json, csv or tsv (default text).",
+	},
+}
+
 type ExecutorMap struct {
-	Map map[string]ExecutorFunc
+	Map   map[string]Command
+	order []string
 }
 
 type ExecutorFunc func(*state.State, Executor) error
 
+// Executor is a parsed invocation of a command. Args holds the
+// positional arguments and Flags holds the values of the flags that
+// were given, keyed by the flag name without the leading dashes.
+// Boolean flags have the value "true". Output is the format that
+// listings are rendered in.
 type Executor struct {
-	Name string
-	Args []string
+	Name   string
+	Args   []string
+	Flags  map[string]string
+	Output output.Format
+}
+
+// Flag returns the value of the flag or an empty string if the flag
+// was not given.
+func (e Executor) Flag(name string) string {
+	return e.Flags[name]
+}
+
+// HasFlag reports whether the flag was given.
+func (e Executor) HasFlag(name string) bool {
+	_, ok := e.Flags[name]
+
+	return ok
+}
+
+// Command declares a command along with the arguments and flags that it
+// accepts. The declaration is used to parse and validate the command
+// line before Run is called and to generate the help for the command.
+type Command struct {
+	Name        string
+	Summary     string
+	Description string
+	Arguments   []Argument
+	Flags       []Flag
+	Run         ExecutorFunc
+}
+
+// Argument is a positional argument of a command. Only the last
+// argument may be variadic, in which case it accepts any number of
+// values.
+type Argument struct {
+	Name        string
+	Description string
+	Optional    bool
+	Variadic    bool
+}
+
+// Flag is a flag of a command. Flags without a value are boolean flags.
+type Flag struct {
+	Name        string
+	Value       string
+	Description string
 }
 
-func (e *ExecutorMap) Register(name string, f ExecutorFunc) {
-	e.Map[name] = f
+func NewExecutorMap() *ExecutorMap {
+	executorMap := ExecutorMap{
+		Map: make(map[string]Command),
+	}
+
+	executorMap.Register(Command{
+		Name:    "help",
+		Summary: "Show the list of commands or the help for a command.",
+		Arguments: []Argument{
+			{Name: "command", Description: "The command to show the help for.", Optional: true},
+		},
+		Run: executorMap.help,
+	})
+
+	return &executorMap
+}
+
+func (e *ExecutorMap) Register(command Command) {
+	if _, ok := e.Map[command.Name]; !ok {
+		e.order = append(e.order, command.Name)
+	}
+
+	e.Map[command.Name] = command
 }
 
+// Run parses the arguments of the command and runs it. The help for the
+// command is printed instead if the --help flag is given.
 func (e *ExecutorMap) Run(s *state.State, exe Executor) error {
-	runFunc, ok := e.Map[exe.Name]
+	command, ok := e.Map[exe.Name]
 	if !ok {
-		return fmt.Errorf("unrecognised command: %s", exe.Name)
+		return fmt.Errorf("unrecognised command: %s (run 'gator help' for the list of commands)", exe.Name)
+	}
+
+	parsed, err := command.parse(exe.Args)
+	if err != nil {
+		return err
+	}
+
+	if parsed.HasFlag(helpFlag) {
+		command.printHelp(os.Stdout)
+
+		return nil
+	}
+
+	parsed.Output, err = output.ParseFormat(parsed.Flag(outputFlag))
+	if err != nil {
+		return err
+	}
+
+	// Only the help can be shown without the state.
+	if s == nil && command.Name != "help" {
+		return fmt.Errorf("unable to run %s without the configuration", command.Name)
+	}
+
+	return command.Run(s, parsed)
+}
+
+// IsHelp reports whether the executor only shows help, either through
+// the help command or the --help flag of a command. Help does not need
+// the configuration or the database so the state may be nil.
+func (e *ExecutorMap) IsHelp(exe Executor) bool {
+	if exe.Name == "help" {
+		return true
+	}
+
+	command, ok := e.Map[exe.Name]
+	if !ok {
+		return false
+	}
+
+	parsed, err := command.parse(exe.Args)
+	if err != nil {
+		return false
+	}
+
+	return parsed.HasFlag(helpFlag)
+}
+
+func (e *ExecutorMap) help(_ *state.State, exe Executor) error {
+	if len(exe.Args) == 1 {
+		command, ok := e.Map[exe.Args[0]]
+		if !ok {
+			return fmt.Errorf("unrecognised command: %s", exe.Args[0])
+		}
+
+		command.printHelp(os.Stdout)
+
+		return nil
+	}
+
+	e.printCommands(os.Stdout)
+
+	return nil
+}
+
+func (e *ExecutorMap) printCommands(writer io.Writer) {
+	fmt.Fprintf(writer, "Gator is an RSS feed aggregator for the command line.\n\n")
+	fmt.Fprintf(writer, "Usage: gator <command> [options] [arguments]\n\n")
+	fmt.Fprintf(writer, "Commands:\n")
+
+	tw := tabwriter.NewWriter(writer, 0, 0, 3, ' ', 0)
+
+	for _, name := range e.order {
+		fmt.Fprintf(tw, "  %s\t%s\n", name, e.Map[name].Summary)
+	}
+
+	_ = tw.Flush()
+
+	fmt.Fprintf(writer, "\nRun 'gator help <command>' or 'gator <command> --help' for more information about a command.\n")
+}
+
+// parse splits the command line into flags and positional arguments
+// and validates them against the declaration of the command. Flags may
+// appear anywhere on the command line and their values may be given
+// either as the next argument or after an equals sign. Everything after
+// "--" is treated as a positional argument.
+func (c Command) parse(args []string) (Executor, error) {
+	exe := Executor{
+		Name:  c.Name,
+		Args:  make([]string, 0, len(args)),
+		Flags: make(map[string]string),
+	}
+
+	for ind := 0; ind < len(args); ind++ {
+		arg := args[ind]
+
+		if arg == "--" {
+			exe.Args = append(exe.Args, args[ind+1:]...)
+
+			break
+		}
+
+		if arg == "-h" || arg == "--help" {
+			exe.Flags[helpFlag] = "true"
+
+			return exe, nil
+		}
+
+		if !strings.HasPrefix(arg, "--") || len(arg) == 2 {
+			exe.Args = append(exe.Args, arg)
+
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(arg[2:], "=")
+
+		flag, ok := c.flag(name)
+		if !ok {
+			return exe, fmt.Errorf("unknown option --%s (usage: %s)", name, c.synopsis())
+		}
+
+		switch {
+		case flag.Value == "" && hasValue:
+			return exe, fmt.Errorf("the --%s option does not take a value", name)
+		case flag.Value == "":
+			value = "true"
+		case !hasValue:
+			if ind+1 == len(args) {
+				return exe, fmt.Errorf("missing the value of --%s (usage: %s)", name, c.synopsis())
+			}
+
+			ind++
+			value = args[ind]
+		}
+
+		exe.Flags[name] = value
+	}
+
+	if err := c.validateArgs(exe.Args); err != nil {
+		return exe, err
+	}
+
+	return exe, nil
+}
+
+func (c Command) flag(name string) (Flag, bool) {
+	for _, flags := range [][]Flag{c.Flags, globalFlags} {
+		for _, flag := range flags {
+			if flag.Name == name {
+				return flag, true
+			}
+		}
+	}
+
+	return Flag{}, false
+}
+
+func (c Command) validateArgs(args []string) error {
+	required := 0
+	variadic := false
+
+	for _, arg := range c.Arguments {
+		if !arg.Optional {
+			required++
+		}
+
+		if arg.Variadic {
+			variadic = true
+		}
+	}
+
+	if len(args) < required {
+		return fmt.Errorf(
+			"missing the %s argument (usage: %s)",
+			c.Arguments[len(args)].display(),
+			c.synopsis(),
+		)
+	}
+
+	if !variadic && len(args) > len(c.Arguments) {
+		return fmt.Errorf(
+			"too many arguments: want at most %d, got %d (usage: %s)",
+			len(c.Arguments),
+			len(args),
+			c.synopsis(),
+		)
+	}
+
+	return nil
+}
+
+// synopsis returns the one line summary of how the command is used.
+func (c Command) synopsis() string {
+	parts := []string{"gator", c.Name, "[options]"}
+
+	for _, arg := range c.Arguments {
+		parts = append(parts, arg.display())
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (c Command) printHelp(writer io.Writer) {
+	fmt.Fprintf(writer, "Usage: %s\n\n%s\n", c.synopsis(), c.Summary)
+
+	if c.Description != "" {
+		fmt.Fprintf(writer, "\n%s\n", c.Description)
+	}
+
+	tw := tabwriter.NewWriter(writer, 0, 0, 3, ' ', 0)
+
+	if len(c.Arguments) > 0 {
+		fmt.Fprintf(tw, "\nArguments:\n")
+
+		for _, arg := range c.Arguments {
+			fmt.Fprintf(tw, "  %s\t%s\n", arg.Name, arg.Description)
+		}
+	}
+
+	if len(c.Flags) > 0 {
+		fmt.Fprintf(tw, "\nOptions:\n")
+		printFlags(tw, c.Flags)
+	}
+
+	fmt.Fprintf(tw, "\nGlobal options:\n")
+	printFlags(tw, globalFlags)
+	fmt.Fprintf(tw, "  -h, --help\tShow this help message.\n")
+
+	_ = tw.Flush()
+}
+
+func printFlags(writer io.Writer, flags []Flag) {
+	for _, flag := range flags {
+		name := "--" + flag.Name
+		if flag.Value != "" {
+			name += " <" + flag.Value + ">"
+		}
+
+		fmt.Fprintf(writer, "  %s\t%s\n", name, flag.Description)
+	}
+}
+
+// render writes the records of a listing in the output format of the
+// command. The text function writes the listing for the text format.
+func render(exe Executor, records any, text func(io.Writer) error) error {
+	if err := output.Render(os.Stdout, exe.Output, records, text); err != nil {
+		return fmt.Errorf("unable to render the output: %w", err)
+	}
+
+	return nil
+}
+
+func (a Argument) display() string {
+	name := a.Name
+	if a.Variadic {
+		name += "..."
+	}
+
+	if a.Optional {
+		return "[" + name + "]"
 	}
 
-	return runFunc(s, exe)
+	return "<" + name + ">"
 }
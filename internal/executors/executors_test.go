@@ -0,0 +1,192 @@
+package executors
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestCommandParse(t *testing.T) {
+	t.Parallel()
+
+	command := Command{
+		Name: "test",
+		Arguments: []Argument{
+			{Name: "id"},
+		},
+		Flags: []Flag{
+			{Name: "note", Value: "text"},
+			{Name: "all"},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		args      []string
+		wantArgs  []string
+		wantFlags map[string]string
+		wantErr   bool
+	}{
+		{
+			name:      "argument and flags",
+			args:      []string{"1", "--note", "hello", "--all"},
+			wantArgs:  []string{"1"},
+			wantFlags: map[string]string{"note": "hello", "all": "true"},
+		},
+		{
+			name:      "value after an equals sign",
+			args:      []string{"--note=hello", "1"},
+			wantArgs:  []string{"1"},
+			wantFlags: map[string]string{"note": "hello"},
+		},
+		{
+			name:      "value that looks like the help flag",
+			args:      []string{"1", "--note", "--help"},
+			wantArgs:  []string{"1"},
+			wantFlags: map[string]string{"note": "--help"},
+		},
+		{
+			name:      "value that looks like the short help flag",
+			args:      []string{"1", "--note", "-h"},
+			wantArgs:  []string{"1"},
+			wantFlags: map[string]string{"note": "-h"},
+		},
+		{
+			name:      "help flag",
+			args:      []string{"--help"},
+			wantArgs:  []string{},
+			wantFlags: map[string]string{helpFlag: "true"},
+		},
+		{
+			name:      "help flag after the separator",
+			args:      []string{"--", "--help"},
+			wantArgs:  []string{"--help"},
+			wantFlags: map[string]string{},
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"1", "--unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			args:    []string{"1", "--note"},
+			wantErr: true,
+		},
+		{
+			name:    "value given to a boolean flag",
+			args:    []string{"1", "--all=yes"},
+			wantErr: true,
+		},
+		{
+			name:    "missing argument",
+			args:    []string{"--all"},
+			wantErr: true,
+		},
+		{
+			name:    "too many arguments",
+			args:    []string{"1", "2"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := command.parse(tc.args)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parse(%q) returned no error", tc.args)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parse(%q) returned an error: %v", tc.args, err)
+			}
+
+			if !slices.Equal(got.Args, tc.wantArgs) {
+				t.Errorf("parse(%q) args = %q, want %q", tc.args, got.Args, tc.wantArgs)
+			}
+
+			if !maps.Equal(got.Flags, tc.wantFlags) {
+				t.Errorf("parse(%q) flags = %v, want %v", tc.args, got.Flags, tc.wantFlags)
+			}
+		})
+	}
+}
+
+func TestIsHelp(t *testing.T) {
+	t.Parallel()
+
+	executorMap := NewExecutorMap()
+
+	for _, command := range Commands() {
+		executorMap.Register(command)
+	}
+
+	testCases := []struct {
+		name string
+		exe  Executor
+		want bool
+	}{
+		{
+			name: "help command",
+			exe:  Executor{Name: "help"},
+			want: true,
+		},
+		{
+			name: "help for a command",
+			exe:  Executor{Name: "help", Args: []string{"browse"}},
+			want: true,
+		},
+		{
+			name: "help flag",
+			exe:  Executor{Name: "browse", Args: []string{"--help"}},
+			want: true,
+		},
+		{
+			name: "short help flag after other arguments",
+			exe:  Executor{Name: "star", Args: []string{"id", "-h"}},
+			want: true,
+		},
+		{
+			name: "help flag as the value of another flag",
+			exe:  Executor{Name: "browse", Args: []string{"--feed", "--help"}},
+			want: false,
+		},
+		{
+			name: "short help flag as the value of another flag",
+			exe:  Executor{Name: "star", Args: []string{"id", "--note", "-h"}},
+			want: false,
+		},
+		{
+			name: "help flag after the separator",
+			exe:  Executor{Name: "follow", Args: []string{"--", "--help"}},
+			want: false,
+		},
+		{
+			name: "command without help",
+			exe:  Executor{Name: "browse", Args: []string{"10"}},
+			want: false,
+		},
+		{
+			name: "unknown command",
+			exe:  Executor{Name: "unknown", Args: []string{"--help"}},
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := executorMap.IsHelp(tc.exe); got != tc.want {
+				t.Errorf("IsHelp(%s %q) = %t, want %t", tc.exe.Name, tc.exe.Args, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/opml"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+)
+
+func ExportOPML(s *state.State, exe Executor, user database.User) error {
+	feeds, err := s.DB.GetFollowedFeedsForUser(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("unable to get the followed feeds from the database: %w", err)
+	}
+
+	subscriptions := make([]opml.Subscription, len(feeds))
+
+	for ind, feed := range feeds {
+		subscriptions[ind] = opml.Subscription{
+			Title:    feed.Name,
+			XMLURL:   feed.Url,
+			HTMLURL:  feed.SiteUrl,
+			Category: feed.Category,
+		}
+	}
+
+	title := fmt.Sprintf("Feeds followed by %s", user.Name)
+
+	if len(exe.Args) == 0 {
+		return writeOPML(os.Stdout, title, subscriptions)
+	}
+
+	path := exe.Args[0]
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to create %s: %w", path, err)
+	}
+
+	if err := writeOPML(file, title, subscriptions); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("unable to close %s: %w", path, err)
+	}
+
+	fmt.Printf("Exported %d feeds to %s.\n", len(subscriptions), path)
+
+	return nil
+}
+
+func writeOPML(writer io.Writer, title string, subscriptions []opml.Subscription) error {
+	if err := opml.Write(writer, title, subscriptions); err != nil {
+		return fmt.Errorf("unable to write the OPML document: %w", err)
+	}
+
+	return nil
+}
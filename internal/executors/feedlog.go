@@ -0,0 +1,98 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+)
+
+// fetchLogRecord is a fetch attempt in the output of the feed-log
+// command. Error is empty if the fetch succeeded.
+type fetchLogRecord struct {
+	StartedAt    time.Time `json:"started_at"`
+	FinishedAt   time.Time `json:"finished_at"`
+	HTTPStatus   int32     `json:"http_status"`
+	Bytes        int64     `json:"bytes"`
+	ItemCount    int32     `json:"item_count"`
+	NewPostCount int32     `json:"new_post_count"`
+	Error        string    `json:"error"`
+}
+
+func FeedLog(s *state.State, exe Executor) error {
+	var err error
+
+	url := exe.Args[0]
+	limit := 10
+
+	if len(exe.Args) == 2 {
+		limit, err = strconv.Atoi(exe.Args[1])
+		if err != nil {
+			return fmt.Errorf("unable to convert %s to a number: %w", exe.Args[1], err)
+		}
+	}
+
+	feed, err := s.DB.GetFeedByUrl(context.Background(), url)
+	if err != nil {
+		return fmt.Errorf("unable to get the feed data from the database: %w", err)
+	}
+
+	args := database.GetFetchLogForFeedParams{
+		FeedID: feed.ID,
+		Limit:  int32(limit),
+	}
+
+	entries, err := s.DB.GetFetchLogForFeed(context.Background(), args)
+	if err != nil {
+		return fmt.Errorf("unable to get the fetch log from the database: %w", err)
+	}
+
+	records := make([]fetchLogRecord, len(entries))
+
+	for ind, entry := range entries {
+		records[ind] = fetchLogRecord{
+			StartedAt:    entry.StartedAt,
+			FinishedAt:   entry.FinishedAt,
+			HTTPStatus:   entry.HttpStatus,
+			Bytes:        entry.Bytes,
+			ItemCount:    entry.ItemCount,
+			NewPostCount: entry.NewPostCount,
+			Error:        entry.Error,
+		}
+	}
+
+	return render(exe, records, func(writer io.Writer) error {
+		if len(records) == 0 {
+			fmt.Fprintf(writer, "There are no recorded fetches of %q.\n", feed.Name)
+
+			return nil
+		}
+
+		fmt.Fprintf(writer, "\nFetch log for %q:\n\n", feed.Name)
+
+		for _, entry := range records {
+			result := "OK"
+			if entry.Error != "" {
+				result = "ERROR: " + entry.Error
+			}
+
+			fmt.Fprintf(
+				writer,
+				"- Started at: %s\n  Duration: %s\n  HTTP status: %d\n  Bytes: %d\n  Items: %d\n  New posts: %d\n  Result: %s\n",
+				entry.StartedAt,
+				entry.FinishedAt.Sub(entry.StartedAt),
+				entry.HTTPStatus,
+				entry.Bytes,
+				entry.ItemCount,
+				entry.NewPostCount,
+				result,
+			)
+		}
+
+		return nil
+	})
+}
@@ -3,19 +3,28 @@ package executors
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
 )
 
-func Feeds(s *state.State, _ Executor) error {
+// feedRecord is a feed in the output of the feeds command.
+type feedRecord struct {
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	SiteURL   string `json:"site_url"`
+	CreatedBy string `json:"created_by"`
+}
+
+func Feeds(s *state.State, exe Executor) error {
 	feeds, err := s.DB.GetAllFeeds(context.Background())
 	if err != nil {
 		return fmt.Errorf("unable to get the feeds from the database: %w", err)
 	}
 
-	fmt.Printf("Feeds:\n\n")
+	records := make([]feedRecord, len(feeds))
 
-	for _, feed := range feeds {
+	for ind, feed := range feeds {
 		user, err := s.DB.GetUserByID(context.Background(), feed.UserID)
 		if err != nil {
 			return fmt.Errorf(
@@ -25,13 +34,27 @@ func Feeds(s *state.State, _ Executor) error {
 			)
 		}
 
-		fmt.Printf(
-			"- Name: %s\n  URL: %s\n  Created by: %s\n",
-			feed.Name,
-			feed.Url,
-			user.Name,
-		)
+		records[ind] = feedRecord{
+			Name:      feed.Name,
+			URL:       feed.Url,
+			SiteURL:   feed.SiteUrl,
+			CreatedBy: user.Name,
+		}
 	}
 
-	return nil
+	return render(exe, records, func(writer io.Writer) error {
+		fmt.Fprintf(writer, "Feeds:\n\n")
+
+		for _, feed := range records {
+			fmt.Fprintf(
+				writer,
+				"- Name: %s\n  URL: %s\n  Created by: %s\n",
+				feed.Name,
+				feed.URL,
+				feed.CreatedBy,
+			)
+		}
+
+		return nil
+	})
 }
@@ -12,16 +12,6 @@ import (
 )
 
 func Follow(s *state.State, exe Executor, user database.User) error {
-	wantNumArgs := 1
-
-	if len(exe.Args) != wantNumArgs {
-		return fmt.Errorf(
-			"unexpected number of arguments: want %d, got %d",
-			wantNumArgs,
-			len(exe.Args),
-		)
-	}
-
 	url := exe.Args[0]
 
 	feed, err := s.DB.GetFeedByUrl(context.Background(), url)
@@ -49,7 +39,6 @@ func Follow(s *state.State, exe Executor, user database.User) error {
 	}
 
 	fmt.Printf("You are now following the feed %q.\n", followRecord.FeedName)
-	fmt.Println("DEBUG:", followRecord)
 
 	return nil
 }
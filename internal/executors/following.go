@@ -3,28 +3,53 @@ package executors
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
 	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
 )
 
-func Following(s *state.State, _ Executor, user database.User) error {
-	following, err := s.DB.GetFeedFollowsForUser(context.Background(), user.ID)
+// followingRecord is a followed feed in the output of the following
+// command. Category is empty if the feed is not in a category.
+type followingRecord struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Category string `json:"category"`
+}
+
+func Following(s *state.State, exe Executor, user database.User) error {
+	following, err := s.DB.GetFollowedFeedsForUser(context.Background(), user.ID)
 	if err != nil {
 		return fmt.Errorf("unable to get the list of feeds from the database: %w", err)
 	}
 
-	if len(following) == 0 {
-		fmt.Println("You are not following any feeds.")
+	records := make([]followingRecord, len(following))
 
-		return nil
+	for ind, feed := range following {
+		records[ind] = followingRecord{
+			Name:     feed.Name,
+			URL:      feed.Url,
+			Category: feed.Category,
+		}
 	}
 
-	fmt.Printf("\nYou are following:\n\n")
+	return render(exe, records, func(writer io.Writer) error {
+		if len(records) == 0 {
+			fmt.Fprintln(writer, "You are not following any feeds.")
 
-	for _, feed := range following {
-		fmt.Printf("- %s\n", feed)
-	}
+			return nil
+		}
 
-	return nil
+		fmt.Fprintf(writer, "\nYou are following:\n\n")
+
+		for _, feed := range records {
+			if feed.Category != "" {
+				fmt.Fprintf(writer, "- %s (%s)\n", feed.Name, feed.Category)
+			} else {
+				fmt.Fprintf(writer, "- %s\n", feed.Name)
+			}
+		}
+
+		return nil
+	})
 }
@@ -0,0 +1,57 @@
+package executors
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// hostLimiter limits the number of concurrent requests made to each
+// host so that the aggregator does not overload a single server.
+type hostLimiter struct {
+	mu    sync.Mutex
+	limit int
+	slots map[string]chan struct{}
+}
+
+func newHostLimiter(limit int) *hostLimiter {
+	return &hostLimiter{
+		mu:    sync.Mutex{},
+		limit: limit,
+		slots: make(map[string]chan struct{}),
+	}
+}
+
+// acquire blocks until a connection slot for the host of the URL is
+// available and returns the function that releases the slot.
+func (h *hostLimiter) acquire(rawURL string) func() {
+	slot := h.slot(hostOf(rawURL))
+
+	slot <- struct{}{}
+
+	return func() {
+		<-slot
+	}
+}
+
+func (h *hostLimiter) slot(host string) chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	slot, ok := h.slots[host]
+	if !ok {
+		slot = make(chan struct{}, h.limit)
+		h.slots[host] = slot
+	}
+
+	return slot
+}
+
+func hostOf(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	return strings.ToLower(parsed.Hostname())
+}
@@ -0,0 +1,140 @@
+package executors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/opml"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+	"github.com/google/uuid"
+)
+
+type importOutcome int
+
+const (
+	importCreated importOutcome = iota
+	importExisting
+)
+
+func ImportOPML(s *state.State, exe Executor, user database.User) error {
+	path := exe.Args[0]
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	subscriptions, err := opml.Parse(file)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	var (
+		created  int
+		existing int
+		failures []string
+	)
+
+	for _, subscription := range subscriptions {
+		outcome, err := importSubscription(s, user, subscription)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", subscription.XMLURL, err))
+
+			continue
+		}
+
+		switch outcome {
+		case importCreated:
+			created++
+		case importExisting:
+			existing++
+		}
+	}
+
+	fmt.Printf(
+		"Imported %s.\n\nCreated: %d\nExisting: %d\nFailed: %d\n",
+		path,
+		created,
+		existing,
+		len(failures),
+	)
+
+	if len(failures) > 0 {
+		fmt.Printf("\nFailed entries:\n\n")
+
+		for _, failure := range failures {
+			fmt.Printf("- %s\n", failure)
+		}
+	}
+
+	return nil
+}
+
+// importSubscription follows the feed of the subscription, adding the
+// feed to the database first if nobody has added it yet. The outcome is
+// importExisting if the user is already following the feed.
+func importSubscription(s *state.State, user database.User, subscription opml.Subscription) (importOutcome, error) {
+	parsedURL, err := url.Parse(subscription.XMLURL)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse the feed URL: %w", err)
+	}
+
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return 0, fmt.Errorf("unsupported URL scheme %q", parsedURL.Scheme)
+	}
+
+	timestamp := time.Now()
+
+	feed, err := s.DB.GetFeedByUrl(context.Background(), subscription.XMLURL)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("unable to get the feed data from the database: %w", err)
+		}
+
+		name := subscription.Title
+		if name == "" {
+			name = subscription.XMLURL
+		}
+
+		createFeedArgs := database.CreateFeedParams{
+			ID:        uuid.New(),
+			CreatedAt: timestamp,
+			UpdatedAt: timestamp,
+			Name:      name,
+			Url:       subscription.XMLURL,
+			UserID:    user.ID,
+			SiteUrl:   subscription.HTMLURL,
+		}
+
+		feed, err = s.DB.CreateFeed(context.Background(), createFeedArgs)
+		if err != nil {
+			return 0, fmt.Errorf("unable to add the feed: %w", err)
+		}
+	}
+
+	createFeedFollowArgs := database.CreateFeedFollowParams{
+		ID:        uuid.New(),
+		CreatedAt: timestamp,
+		UpdatedAt: timestamp,
+		UserID:    user.ID,
+		FeedID:    feed.ID,
+		Category:  subscription.Category,
+	}
+
+	if _, err := s.DB.CreateFeedFollow(context.Background(), createFeedFollowArgs); err != nil {
+		if uniqueViolation(err) {
+			return importExisting, nil
+		}
+
+		return 0, fmt.Errorf("unable to create the feed follow record in the database: %w", err)
+	}
+
+	return importCreated, nil
+}
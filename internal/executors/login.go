@@ -8,10 +8,6 @@ import (
 )
 
 func Login(s *state.State, exe Executor) error {
-	if len(exe.Args) != 1 {
-		return fmt.Errorf("unexpected number of arguments: want 1, got %d", len(exe.Args))
-	}
-
 	username := exe.Args[0]
 
 	user, err := s.DB.GetUserByName(context.Background(), username)
@@ -0,0 +1,25 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+)
+
+func PruneFeedLog(s *state.State, exe Executor) error {
+	maxAge, err := time.ParseDuration(exe.Args[0])
+	if err != nil {
+		return fmt.Errorf("unable to parse the maximum age: %w", err)
+	}
+
+	deleted, err := s.DB.DeleteFetchLogBefore(context.Background(), time.Now().Add(-maxAge))
+	if err != nil {
+		return fmt.Errorf("unable to prune the fetch log: %w", err)
+	}
+
+	fmt.Printf("Removed %d entries older than %s from the fetch log.\n", deleted, maxAge)
+
+	return nil
+}
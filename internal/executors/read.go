@@ -0,0 +1,106 @@
+package executors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+	"github.com/google/uuid"
+)
+
+// postSelection is the set of posts that the read and unread commands
+// act on. It is either a single post, every post of a feed or every
+// post from the feeds that the user follows.
+type postSelection struct {
+	all     bool
+	feedURL string
+	postID  uuid.UUID
+}
+
+func parsePostSelection(exe Executor) (postSelection, error) {
+	given := len(exe.Args)
+
+	for _, flag := range []string{"all", "feed"} {
+		if exe.HasFlag(flag) {
+			given++
+		}
+	}
+
+	if given != 1 {
+		return postSelection{}, errors.New("please specify one of a post ID, --all or --feed <url>")
+	}
+
+	switch {
+	case exe.HasFlag("all"):
+		return postSelection{all: true}, nil
+	case exe.HasFlag("feed"):
+		return postSelection{feedURL: exe.Flag("feed")}, nil
+	}
+
+	postID, err := uuid.Parse(exe.Args[0])
+	if err != nil {
+		return postSelection{}, fmt.Errorf("unable to parse the post ID: %w", err)
+	}
+
+	return postSelection{postID: postID}, nil
+}
+
+func Read(s *state.State, exe Executor, user database.User) error {
+	selection, err := parsePostSelection(exe)
+	if err != nil {
+		return err
+	}
+
+	marked, err := markPostsRead(s, user, selection)
+	if err != nil {
+		return fmt.Errorf("unable to mark the posts as read: %w", err)
+	}
+
+	fmt.Printf("Marked %d post(s) as read.\n", marked)
+
+	return nil
+}
+
+func markPostsRead(s *state.State, user database.User, selection postSelection) (int64, error) {
+	timestamp := time.Now()
+
+	if selection.all {
+		args := database.MarkAllPostsReadParams{
+			UserID: user.ID,
+			ReadAt: timestamp,
+		}
+
+		return s.DB.MarkAllPostsRead(context.Background(), args)
+	}
+
+	if selection.feedURL != "" {
+		feed, err := s.DB.GetFeedByUrl(context.Background(), selection.feedURL)
+		if err != nil {
+			return 0, fmt.Errorf("unable to get the feed data from the database: %w", err)
+		}
+
+		args := database.MarkFeedPostsReadParams{
+			UserID: user.ID,
+			ReadAt: timestamp,
+			FeedID: feed.ID,
+		}
+
+		return s.DB.MarkFeedPostsRead(context.Background(), args)
+	}
+
+	post, err := s.DB.GetPostByID(context.Background(), selection.postID)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get the post from the database: %w", err)
+	}
+
+	args := database.MarkPostReadParams{
+		UserID: user.ID,
+		PostID: post.ID,
+		ReadAt: timestamp,
+	}
+
+	return s.DB.MarkPostRead(context.Background(), args)
+}
@@ -12,10 +12,6 @@ import (
 )
 
 func Register(s *state.State, exe Executor) error {
-	if len(exe.Args) != 1 {
-		return fmt.Errorf("unexpected number of arguments: want 1, got %d", len(exe.Args))
-	}
-
 	name := exe.Args[0]
 
 	timestamp := time.Now()
@@ -41,7 +37,6 @@ func Register(s *state.State, exe Executor) error {
 	}
 
 	fmt.Printf("Successfully registered %s.\n", user.Name)
-	fmt.Println("DEBUG:", user)
 
 	return nil
 }
@@ -9,7 +9,7 @@ import (
 
 func Reset(s *state.State, _ Executor) error {
 	if err := s.DB.DeleteAllUsers(context.Background()); err != nil {
-		fmt.Errorf("unable to delete the users from the database: %w", err)
+		return fmt.Errorf("unable to delete the users from the database: %w", err)
 	}
 
 	fmt.Println("Successfully removed all users from the database.")
@@ -0,0 +1,93 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+	"github.com/google/uuid"
+)
+
+// postVersionRecord is a version of a post in the output of the
+// revisions command. The current version comes first. For the previous
+// revisions UpdatedAt is the time that the revision was replaced.
+type postVersionRecord struct {
+	Current     bool      `json:"current"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+}
+
+func Revisions(s *state.State, exe Executor) error {
+	postID, err := uuid.Parse(exe.Args[0])
+	if err != nil {
+		return fmt.Errorf("unable to parse the post ID: %w", err)
+	}
+
+	post, err := s.DB.GetPostByID(context.Background(), postID)
+	if err != nil {
+		return fmt.Errorf("unable to get the post from the database: %w", err)
+	}
+
+	revisions, err := s.DB.GetPostRevisions(context.Background(), post.ID)
+	if err != nil {
+		return fmt.Errorf("unable to get the revisions of the post from the database: %w", err)
+	}
+
+	records := make([]postVersionRecord, 0, len(revisions)+1)
+
+	records = append(records, postVersionRecord{
+		Current:     true,
+		UpdatedAt:   post.UpdatedAt,
+		Title:       post.Title,
+		URL:         post.Url,
+		Description: post.Description,
+	})
+
+	for _, revision := range revisions {
+		records = append(records, postVersionRecord{
+			Current:     false,
+			UpdatedAt:   revision.CreatedAt,
+			Title:       revision.Title,
+			URL:         revision.Url,
+			Description: revision.Description,
+		})
+	}
+
+	return render(exe, records, func(writer io.Writer) error {
+		current := records[0]
+
+		fmt.Fprintf(
+			writer,
+			"\nCurrent version (updated at %s):\n\n- Title: %s\n  URL: %s\n  Description: %s\n",
+			current.UpdatedAt,
+			current.Title,
+			current.URL,
+			current.Description,
+		)
+
+		if len(records) == 1 {
+			fmt.Fprintln(writer, "\nThere are no previous revisions of this post.")
+
+			return nil
+		}
+
+		fmt.Fprintf(writer, "\nPrevious revisions:\n\n")
+
+		for _, revision := range records[1:] {
+			fmt.Fprintf(
+				writer,
+				"- Replaced at: %s\n  Title: %s\n  URL: %s\n  Description: %s\n",
+				revision.UpdatedAt,
+				revision.Title,
+				revision.URL,
+				revision.Description,
+			)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,96 @@
+package executors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+	"github.com/google/uuid"
+)
+
+// searchResultRecord is a post in the output of the search command.
+// The matching words in the snippet are surrounded by asterisks.
+type searchResultRecord struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Feed        string    `json:"feed"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"published_at"`
+	Rank        float32   `json:"rank"`
+	Snippet     string    `json:"snippet"`
+}
+
+func Search(s *state.State, exe Executor, user database.User) error {
+	var err error
+
+	limit := 10
+
+	if exe.HasFlag("limit") {
+		limit, err = strconv.Atoi(exe.Flag("limit"))
+		if err != nil {
+			return fmt.Errorf("unable to convert %s to a number: %w", exe.Flag("limit"), err)
+		}
+	}
+
+	query := strings.Join(exe.Args, " ")
+	if strings.TrimSpace(query) == "" {
+		return errors.New("please specify the search query")
+	}
+
+	args := database.SearchPostsForUserParams{
+		Query:      query,
+		UserID:     user.ID,
+		MaxResults: int32(limit),
+	}
+
+	results, err := s.DB.SearchPostsForUser(context.Background(), args)
+	if err != nil {
+		return fmt.Errorf("unable to search the posts: %w", err)
+	}
+
+	records := make([]searchResultRecord, len(results))
+
+	for ind, result := range results {
+		records[ind] = searchResultRecord{
+			ID:          result.ID,
+			Title:       result.Title,
+			Feed:        result.FeedName,
+			URL:         result.Url,
+			PublishedAt: result.PublishedAt,
+			Rank:        result.Rank,
+			Snippet:     strings.Join(strings.Fields(html.UnescapeString(result.Snippet)), " "),
+		}
+	}
+
+	return render(exe, records, func(writer io.Writer) error {
+		if len(records) == 0 {
+			fmt.Fprintf(writer, "No posts matched %q.\n", query)
+
+			return nil
+		}
+
+		fmt.Fprintf(writer, "\nResults for %q:\n\n", query)
+
+		for _, result := range records {
+			fmt.Fprintf(
+				writer,
+				"- ID: %s\n  Title: %s\n  Feed: %s\n  URL: %s\n  Published at: %s\n  Snippet: %s\n",
+				result.ID,
+				result.Title,
+				result.Feed,
+				result.URL,
+				result.PublishedAt,
+				result.Snippet,
+			)
+		}
+
+		return nil
+	})
+}
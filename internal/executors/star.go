@@ -0,0 +1,63 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+	"github.com/google/uuid"
+)
+
+func Star(s *state.State, exe Executor, user database.User) error {
+	postID, err := uuid.Parse(exe.Args[0])
+	if err != nil {
+		return fmt.Errorf("unable to parse the post ID: %w", err)
+	}
+
+	post, err := s.DB.GetPostByID(context.Background(), postID)
+	if err != nil {
+		return fmt.Errorf("unable to get the post from the database: %w", err)
+	}
+
+	timestamp := time.Now()
+
+	args := database.StarPostParams{
+		UserID:    user.ID,
+		PostID:    post.ID,
+		Note:      exe.Flag("note"),
+		Tags:      parseTags(exe.Flag("tags")),
+		CreatedAt: timestamp,
+		UpdatedAt: timestamp,
+	}
+
+	if _, err := s.DB.StarPost(context.Background(), args); err != nil {
+		return fmt.Errorf("unable to star the post: %w", err)
+	}
+
+	fmt.Printf("Starred %q.\n", post.Title)
+
+	return nil
+}
+
+// parseTags splits a comma separated list of tags, removing blank and
+// duplicate tags.
+func parseTags(value string) []string {
+	tags := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
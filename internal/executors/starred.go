@@ -0,0 +1,83 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+	"github.com/google/uuid"
+)
+
+// starredRecord is a post in the output of the starred command. In the
+// CSV and TSV output the tags are joined with commas.
+type starredRecord struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"published_at"`
+	StarredAt   time.Time `json:"starred_at"`
+	Tags        []string  `json:"tags"`
+	Note        string    `json:"note"`
+}
+
+func Starred(s *state.State, exe Executor, user database.User) error {
+	args := database.GetStarredPostsForUserParams{
+		UserID: user.ID,
+		Tag:    exe.Flag("tag"),
+	}
+
+	posts, err := s.DB.GetStarredPostsForUser(context.Background(), args)
+	if err != nil {
+		return fmt.Errorf("unable to get the starred posts: %w", err)
+	}
+
+	records := make([]starredRecord, len(posts))
+
+	for ind, post := range posts {
+		records[ind] = starredRecord{
+			ID:          post.ID,
+			Title:       post.Title,
+			URL:         post.Url,
+			PublishedAt: post.PublishedAt,
+			StarredAt:   post.StarredAt,
+			Tags:        post.Tags,
+			Note:        post.Note,
+		}
+	}
+
+	return render(exe, records, func(writer io.Writer) error {
+		if len(records) == 0 {
+			fmt.Fprintln(writer, "There are no starred posts.")
+
+			return nil
+		}
+
+		fmt.Fprintf(writer, "\nStarred posts:\n\n")
+
+		for _, post := range records {
+			fmt.Fprintf(
+				writer,
+				"- ID: %s\n  Title: %s\n  URL: %s\n  Published at: %s\n  Starred at: %s\n",
+				post.ID,
+				post.Title,
+				post.URL,
+				post.PublishedAt,
+				post.StarredAt,
+			)
+
+			if len(post.Tags) > 0 {
+				fmt.Fprintf(writer, "  Tags: %s\n", strings.Join(post.Tags, ", "))
+			}
+
+			if post.Note != "" {
+				fmt.Fprintf(writer, "  Note: %s\n", post.Note)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,17 @@
+package executors
+
+import (
+	"fmt"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/tui"
+)
+
+func TUI(s *state.State, _ Executor, user database.User) error {
+	if err := tui.Run(s.DB, user); err != nil {
+		return fmt.Errorf("unable to run the terminal interface: %w", err)
+	}
+
+	return nil
+}
@@ -9,16 +9,6 @@ import (
 )
 
 func Unfollow(s *state.State, exe Executor, user database.User) error {
-	wantNumArgs := 1
-
-	if len(exe.Args) != wantNumArgs {
-		return fmt.Errorf(
-			"unexpected number of arguments: want %d, got %d",
-			wantNumArgs,
-			len(exe.Args),
-		)
-	}
-
 	url := exe.Args[0]
 
 	feed, err := s.DB.GetFeedByUrl(context.Background(), url)
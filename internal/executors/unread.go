@@ -0,0 +1,52 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+)
+
+func Unread(s *state.State, exe Executor, user database.User) error {
+	selection, err := parsePostSelection(exe)
+	if err != nil {
+		return err
+	}
+
+	marked, err := markPostsUnread(s, user, selection)
+	if err != nil {
+		return fmt.Errorf("unable to mark the posts as unread: %w", err)
+	}
+
+	fmt.Printf("Marked %d post(s) as unread.\n", marked)
+
+	return nil
+}
+
+func markPostsUnread(s *state.State, user database.User, selection postSelection) (int64, error) {
+	if selection.all {
+		return s.DB.MarkAllPostsUnread(context.Background(), user.ID)
+	}
+
+	if selection.feedURL != "" {
+		feed, err := s.DB.GetFeedByUrl(context.Background(), selection.feedURL)
+		if err != nil {
+			return 0, fmt.Errorf("unable to get the feed data from the database: %w", err)
+		}
+
+		args := database.MarkFeedPostsUnreadParams{
+			UserID: user.ID,
+			FeedID: feed.ID,
+		}
+
+		return s.DB.MarkFeedPostsUnread(context.Background(), args)
+	}
+
+	args := database.MarkPostUnreadParams{
+		UserID: user.ID,
+		PostID: selection.postID,
+	}
+
+	return s.DB.MarkPostUnread(context.Background(), args)
+}
@@ -0,0 +1,36 @@
+package executors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
+	"github.com/google/uuid"
+)
+
+func Unstar(s *state.State, exe Executor, user database.User) error {
+	postID, err := uuid.Parse(exe.Args[0])
+	if err != nil {
+		return fmt.Errorf("unable to parse the post ID: %w", err)
+	}
+
+	args := database.UnstarPostParams{
+		UserID: user.ID,
+		PostID: postID,
+	}
+
+	removed, err := s.DB.UnstarPost(context.Background(), args)
+	if err != nil {
+		return fmt.Errorf("unable to unstar the post: %w", err)
+	}
+
+	if removed == 0 {
+		return errors.New("you have not starred this post")
+	}
+
+	fmt.Println("Successfully unstarred the post.")
+
+	return nil
+}
@@ -3,31 +3,49 @@ package executors
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
 )
 
-func Users(s *state.State, _ Executor) error {
+// userRecord is a registered user in the output of the users command.
+type userRecord struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+}
+
+func Users(s *state.State, exe Executor) error {
 	users, err := s.DB.GetAllUsers(context.Background())
 	if err != nil {
-		fmt.Errorf("unable to get the users from the database: %w", err)
+		return fmt.Errorf("unable to get the users from the database: %w", err)
 	}
 
-	if len(users) == 0 {
-		fmt.Println("There are no registered users.")
+	records := make([]userRecord, len(users))
 
-		return nil
+	for ind, user := range users {
+		records[ind] = userRecord{
+			Name:    user.Name,
+			Current: user.Name == s.Config.CurrentUsername,
+		}
 	}
 
-	fmt.Printf("Registered users:\n\n")
+	return render(exe, records, func(writer io.Writer) error {
+		if len(records) == 0 {
+			fmt.Fprintln(writer, "There are no registered users.")
 
-	for _, user := range users {
-		if user.Name == s.Config.CurrentUsername {
-			fmt.Printf("- %s (current)\n", user.Name)
-		} else {
-			fmt.Printf("- %s\n", user.Name)
+			return nil
 		}
-	}
 
-	return nil
+		fmt.Fprintf(writer, "Registered users:\n\n")
+
+		for _, user := range records {
+			if user.Current {
+				fmt.Fprintf(writer, "- %s (current)\n", user.Name)
+			} else {
+				fmt.Fprintf(writer, "- %s\n", user.Name)
+			}
+		}
+
+		return nil
+	})
 }
@@ -1,7 +1,9 @@
 package executors
 
 import (
+	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/lib/pq"
 )
@@ -17,3 +19,21 @@ func uniqueViolation(err error) bool {
 
 	return false
 }
+
+func valueOrDefault(value, defaultValue int) int {
+	if value <= 0 {
+		return defaultValue
+	}
+
+	return value
+}
+
+// nullTimePointer converts a nullable time into a pointer for the
+// machine-readable output so that NULL is rendered as null.
+func nullTimePointer(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.Time
+}
@@ -0,0 +1,368 @@
+// Package htmltext renders the HTML found in feed items as plain text
+// for display in the terminal.
+//
+// The renderer is deliberately simple. It keeps the structure that
+// matters for reading (paragraphs, line breaks, lists, headings and
+// preformatted text), lists the targets of links at the end of the text
+// and drops everything else, including scripts and styles.
+package htmltext
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	attributePattern = regexp.MustCompile(`([A-Za-z_:][-A-Za-z0-9_:.]*)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	whitespace       = regexp.MustCompile(`\s+`)
+)
+
+// blockElements are the elements that start and end a paragraph.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "figcaption": true,
+	"figure": true, "footer": true, "form": true, "h1": true, "h2": true,
+	"h3": true, "h4": true, "h5": true, "h6": true, "header": true,
+	"hr": true, "main": true, "nav": true, "ol": true, "p": true,
+	"pre": true, "section": true, "table": true, "ul": true,
+}
+
+// lineElements are the elements that start on a new line without
+// starting a new paragraph.
+var lineElements = map[string]bool{
+	"br": true, "li": true, "tr": true,
+}
+
+// skippedElements are the elements whose content is not displayed.
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "head": true, "noscript": true, "template": true,
+}
+
+// ToText converts the HTML to plain text. The targets of the links are
+// numbered and listed after the text.
+func ToText(source string) string {
+	var r renderer
+
+	for len(source) > 0 {
+		start := strings.IndexByte(source, '<')
+		if start == -1 {
+			r.text(source)
+
+			break
+		}
+
+		r.text(source[:start])
+		source = source[start:]
+
+		if strings.HasPrefix(source, "<!--") {
+			end := strings.Index(source, "-->")
+			if end == -1 {
+				break
+			}
+
+			source = source[end+3:]
+
+			continue
+		}
+
+		end := strings.IndexByte(source, '>')
+		if end == -1 {
+			r.text(source)
+
+			break
+		}
+
+		r.tag(source[1:end])
+		source = source[end+1:]
+	}
+
+	return r.String()
+}
+
+type renderer struct {
+	builder   strings.Builder
+	pending   string
+	skipping  string
+	preDepth  int
+	listDepth int
+	href      string
+	links     []string
+}
+
+// text appends the text content of the document.
+func (r *renderer) text(value string) {
+	if r.skipping != "" || value == "" {
+		return
+	}
+
+	value = html.UnescapeString(value)
+
+	if r.preDepth == 0 {
+		value = whitespace.ReplaceAllString(value, " ")
+
+		// Whitespace at the start of a line is not significant.
+		if r.atLineStart() {
+			value = strings.TrimLeft(value, " ")
+		}
+
+		if value == "" {
+			return
+		}
+
+		// Only a single space is kept between words.
+		if strings.HasSuffix(r.builder.String(), " ") && r.pending == "" {
+			value = strings.TrimLeft(value, " ")
+		}
+	}
+
+	r.flush()
+	r.builder.WriteString(value)
+}
+
+// tag handles the tag with the given content, which excludes the angle
+// brackets.
+func (r *renderer) tag(content string) {
+	closing := strings.HasPrefix(content, "/")
+	content = strings.TrimPrefix(content, "/")
+
+	name := content
+	if ind := strings.IndexAny(content, " \t\r\n/"); ind != -1 {
+		name = content[:ind]
+	}
+
+	name = strings.ToLower(name)
+
+	// Skipping stops at the end of the skipped element or, when the
+	// element is not closed, at the next block so that a stray tag does
+	// not hide the rest of the document.
+	if r.skipping != "" {
+		if closing && name == r.skipping {
+			r.skipping = ""
+
+			return
+		}
+
+		if !blockElements[name] {
+			return
+		}
+
+		r.skipping = ""
+	}
+
+	switch {
+	case skippedElements[name] && !closing && !strings.HasSuffix(content, "/"):
+		r.skipping = name
+	case name == "pre":
+		r.paragraph()
+
+		if closing {
+			r.preDepth = max(r.preDepth-1, 0)
+		} else {
+			r.preDepth++
+		}
+	case name == "ul" || name == "ol":
+		r.paragraph()
+
+		if closing {
+			r.listDepth = max(r.listDepth-1, 0)
+		} else {
+			r.listDepth++
+		}
+	case name == "li" && !closing:
+		r.line()
+		r.pending += strings.Repeat("  ", max(r.listDepth-1, 0)) + "• "
+	case name == "hr" && !closing:
+		r.paragraph()
+		r.pending += "────────"
+		r.flush()
+		r.paragraph()
+	case name == "a":
+		r.link(closing, content)
+	case name == "img" && !closing:
+		if alt := attributes(content)["alt"]; alt != "" {
+			r.text("[image: " + alt + "]")
+		}
+	case blockElements[name]:
+		r.paragraph()
+	case lineElements[name]:
+		r.line()
+	}
+}
+
+// link remembers the target of an opening link and adds the number of
+// the link to the text when the link is closed.
+func (r *renderer) link(closing bool, content string) {
+	if !closing {
+		r.href = strings.TrimSpace(attributes(content)["href"])
+
+		return
+	}
+
+	if r.href == "" || strings.HasPrefix(r.href, "#") {
+		r.href = ""
+
+		return
+	}
+
+	r.links = append(r.links, r.href)
+	r.href = ""
+
+	r.flush()
+	fmt.Fprintf(&r.builder, "[%d]", len(r.links))
+}
+
+// paragraph ends the current paragraph. A pending list marker is kept
+// so that paragraphs inside list items stay on the line of the marker.
+func (r *renderer) paragraph() {
+	if r.builder.Len() > 0 && !strings.HasSuffix(r.pending, "• ") {
+		r.pending = "\n\n"
+	}
+}
+
+// line ends the current line.
+func (r *renderer) line() {
+	if r.builder.Len() > 0 && r.pending == "" {
+		r.pending = "\n"
+	}
+}
+
+// flush writes the pending line breaks and list markers before the
+// next piece of text.
+func (r *renderer) flush() {
+	if r.pending == "" {
+		return
+	}
+
+	text := strings.TrimRight(r.builder.String(), " ")
+	r.builder.Reset()
+	r.builder.WriteString(text)
+	r.builder.WriteString(r.pending)
+	r.pending = ""
+}
+
+func (r *renderer) atLineStart() bool {
+	text := r.builder.String()
+
+	return r.pending != "" || text == "" || strings.HasSuffix(text, "\n")
+}
+
+func (r *renderer) String() string {
+	text := strings.TrimSpace(r.builder.String())
+
+	if len(r.links) == 0 {
+		return text
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(text)
+	builder.WriteString("\n\nLinks:\n")
+
+	for ind, link := range r.links {
+		fmt.Fprintf(&builder, "[%d] %s\n", ind+1, link)
+	}
+
+	return strings.TrimRight(builder.String(), "\n")
+}
+
+// attributes returns the attributes of a tag keyed by their lower case
+// names.
+func attributes(content string) map[string]string {
+	values := make(map[string]string)
+
+	for _, match := range attributePattern.FindAllStringSubmatch(content, -1) {
+		name := strings.ToLower(match[1])
+		if _, ok := values[name]; ok {
+			continue
+		}
+
+		values[name] = html.UnescapeString(match[2] + match[3] + match[4])
+	}
+
+	return values
+}
+
+// Wrap wraps the text to the given width. Existing line breaks are
+// kept and words longer than the width are split.
+func Wrap(text string, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+
+	var lines []string
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		if paragraph == "" {
+			lines = append(lines, "")
+
+			continue
+		}
+
+		// Continuation lines are indented to match the leading
+		// whitespace and list marker of the paragraph.
+		indent := leadingIndent(paragraph)
+		prefix := paragraph[:len(paragraph)-len(strings.TrimLeft(paragraph, " "))]
+
+		var line strings.Builder
+
+		line.WriteString(prefix)
+		lineWidth := utf8.RuneCountInString(prefix)
+		empty := true
+
+		newLine := func() {
+			lines = append(lines, line.String())
+			line.Reset()
+			line.WriteString(indent)
+			lineWidth = utf8.RuneCountInString(indent)
+			empty = true
+		}
+
+		for _, word := range strings.Fields(paragraph) {
+			wordWidth := utf8.RuneCountInString(word)
+
+			if !empty && lineWidth+1+wordWidth > width {
+				newLine()
+			}
+
+			if !empty {
+				line.WriteByte(' ')
+				lineWidth++
+			}
+
+			for wordWidth > width-lineWidth && width-lineWidth > 0 {
+				runes := []rune(word)
+				cut := width - lineWidth
+
+				line.WriteString(string(runes[:cut]))
+				newLine()
+
+				word = string(runes[cut:])
+				wordWidth -= cut
+			}
+
+			line.WriteString(word)
+			lineWidth += wordWidth
+			empty = false
+		}
+
+		lines = append(lines, line.String())
+	}
+
+	return lines
+}
+
+// leadingIndent returns the whitespace for the continuation lines of a
+// paragraph, which includes the width of a leading list marker.
+func leadingIndent(paragraph string) string {
+	trimmed := strings.TrimLeft(paragraph, " ")
+	indent := len(paragraph) - len(trimmed)
+
+	if strings.HasPrefix(trimmed, "• ") {
+		indent += 2
+	}
+
+	return strings.Repeat(" ", indent)
+}
@@ -0,0 +1,164 @@
+package htmltext_test
+
+import (
+	"slices"
+	"testing"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/htmltext"
+)
+
+func TestToText(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "plain text",
+			html: "Hello, world",
+			want: "Hello, world",
+		},
+		{
+			name: "entities",
+			html: "<p>Fish &amp; chips &lt;3 &quot;tasty&quot; &#8211; &eacute;</p>",
+			want: "Fish & chips <3 \"tasty\" – é",
+		},
+		{
+			name: "escaped markup stays text",
+			html: "<p>Use &lt;script&gt; tags</p>",
+			want: "Use <script> tags",
+		},
+		{
+			name: "collapsed whitespace",
+			html: "<p>  one\n\ttwo   three </p>",
+			want: "one two three",
+		},
+		{
+			name: "paragraphs and line breaks",
+			html: "<h1>Title</h1><p>First<br>line</p><p>Second</p>",
+			want: "Title\n\nFirst\nline\n\nSecond",
+		},
+		{
+			name: "lists",
+			html: "<p>Items:</p><ul><li>one</li><li><p>two</p></li><ul><li>nested</li></ul></ul>",
+			want: "Items:\n\n• one\n• two\n\n  • nested",
+		},
+		{
+			name: "preformatted text",
+			html: "<p>Code:</p><pre>if x {\n  y()\n}</pre>",
+			want: "Code:\n\nif x {\n  y()\n}",
+		},
+		{
+			name: "links",
+			html: `<p>See <a href="https://example.com/a">this</a> and <a href="#top">that</a>.</p>`,
+			want: "See this[1] and that.\n\nLinks:\n[1] https://example.com/a",
+		},
+		{
+			name: "images",
+			html: `<p><img src="cat.png" alt="A cat"> <img src="spacer.gif"></p>`,
+			want: "[image: A cat]",
+		},
+		{
+			name: "comments",
+			html: "<p>Before<!-- <b>hidden</b> --> after</p>",
+			want: "Before after",
+		},
+		{
+			name: "scripts and styles",
+			html: "<style>p { color: red; }</style><p>Text</p><script>alert('<b>');</script>",
+			want: "Text",
+		},
+		{
+			name: "unclosed script",
+			html: "<p>Use <script> tag</p><p>more text</p>",
+			want: "Use\n\nmore text",
+		},
+		{
+			name: "unclosed tag at the end",
+			html: "<p>Text <b",
+			want: "Text <b",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := htmltext.ToText(tc.html); got != tc.want {
+				t.Errorf("ToText(%q) = %q, want %q", tc.html, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{
+			name:  "short line",
+			text:  "one two",
+			width: 20,
+			want:  []string{"one two"},
+		},
+		{
+			name:  "wrapped at word boundaries",
+			text:  "the quick brown fox jumps",
+			width: 10,
+			want:  []string{"the quick", "brown fox", "jumps"},
+		},
+		{
+			name:  "blank lines are kept",
+			text:  "one\n\ntwo",
+			width: 10,
+			want:  []string{"one", "", "two"},
+		},
+		{
+			name:  "long words are split",
+			text:  "a abcdefghij",
+			width: 4,
+			want:  []string{"a", "abcd", "efgh", "ij"},
+		},
+		{
+			name:  "list items are indented",
+			text:  "• one two three",
+			width: 9,
+			want:  []string{"• one two", "  three"},
+		},
+		{
+			name:  "nested list items are indented",
+			text:  "  • one two three",
+			width: 11,
+			want:  []string{"  • one two", "    three"},
+		},
+		{
+			name:  "narrow width",
+			text:  "abc",
+			width: 1,
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "zero width",
+			text:  "abc",
+			width: 0,
+			want:  nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := htmltext.Wrap(tc.text, tc.width); !slices.Equal(got, tc.want) {
+				t.Errorf("Wrap(%q, %d) = %q, want %q", tc.text, tc.width, got, tc.want)
+			}
+		})
+	}
+}
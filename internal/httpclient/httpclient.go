@@ -0,0 +1,192 @@
+// Package httpclient builds the HTTP client used for every network
+// request that gator makes.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/config"
+)
+
+const (
+	userAgent = "Gator/0.0.0"
+
+	defaultConnectTimeout        = 10 * time.Second
+	defaultResponseHeaderTimeout = 30 * time.Second
+	defaultRequestTimeout        = 60 * time.Second
+	defaultMaxBodySize           = 10 * 1024 * 1024
+)
+
+var ErrBodyTooLarge = errors.New("the response body exceeds the maximum size")
+
+// Client is a HTTP client with a limit on the size of the response
+// bodies that it reads.
+type Client struct {
+	HTTPClient  *http.Client
+	MaxBodySize int64
+}
+
+// New returns a new Client configured from the HTTP section of the
+// configuration. Unset values fall back to the defaults.
+func New(cfg config.HTTPConfig) (*Client, error) {
+	connectTimeout, err := parseDuration(cfg.ConnectTimeout, defaultConnectTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the connect timeout: %w", err)
+	}
+
+	responseHeaderTimeout, err := parseDuration(cfg.ResponseHeaderTimeout, defaultResponseHeaderTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the response header timeout: %w", err)
+	}
+
+	requestTimeout, err := parseDuration(cfg.RequestTimeout, defaultRequestTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the request timeout: %w", err)
+	}
+
+	proxy := http.ProxyFromEnvironment
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the proxy URL: %w", err)
+		}
+
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	rootCAs, err := certPool(cfg.CABundle)
+	if err != nil {
+		return nil, err
+	}
+
+	newTransport := func(insecureSkipVerify bool) *http.Transport {
+		dialer := net.Dialer{
+			Timeout:   connectTimeout,
+			KeepAlive: 30 * time.Second,
+		}
+
+		return &http.Transport{
+			Proxy:                 proxy,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   connectTimeout,
+			ResponseHeaderTimeout: responseHeaderTimeout,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			ExpectContinueTimeout: time.Second,
+			TLSClientConfig: &tls.Config{
+				RootCAs:            rootCAs,
+				InsecureSkipVerify: insecureSkipVerify, //nolint:gosec // Only enabled for hosts listed in the configuration.
+				MinVersion:         tls.VersionTLS12,
+			},
+		}
+	}
+
+	insecureHosts := make(map[string]bool)
+
+	for _, host := range cfg.InsecureSkipVerifyHosts {
+		insecureHosts[strings.ToLower(host)] = true
+	}
+
+	maxBodySize := cfg.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = defaultMaxBodySize
+	}
+
+	client := Client{
+		HTTPClient: &http.Client{
+			Timeout: requestTimeout,
+			Transport: &transport{
+				secure:        newTransport(false),
+				insecure:      newTransport(true),
+				insecureHosts: insecureHosts,
+			},
+		},
+		MaxBodySize: maxBodySize,
+	}
+
+	return &client, nil
+}
+
+// ReadBody reads the body of the response up to the maximum size.
+func (c *Client) ReadBody(response *http.Response) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(response.Body, c.MaxBodySize+1))
+	if err != nil {
+		return data, err
+	}
+
+	if int64(len(data)) > c.MaxBodySize {
+		return data[:c.MaxBodySize], fmt.Errorf("%w (%d bytes)", ErrBodyTooLarge, c.MaxBodySize)
+	}
+
+	return data, nil
+}
+
+// transport sets the User-Agent header on every request and skips the
+// verification of TLS certificates for the hosts listed in the
+// configuration. The hosts are matched by name without the port, so
+// the setting applies to every feed on a listed host. The transport is
+// chosen on every request so that redirects to other hosts are
+// verified.
+type transport struct {
+	secure        http.RoundTripper
+	insecure      http.RoundTripper
+	insecureHosts map[string]bool
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Header.Get("User-Agent") == "" {
+		request = request.Clone(request.Context())
+		request.Header.Set("User-Agent", userAgent)
+	}
+
+	if t.insecureHosts[strings.ToLower(request.URL.Hostname())] {
+		return t.insecure.RoundTrip(request)
+	}
+
+	return t.secure.RoundTrip(request)
+}
+
+// certPool returns the system certificate pool with the certificates
+// from the CA bundle added. The system pool is used as it is if no CA
+// bundle is configured.
+func certPool(caBundle string) (*x509.CertPool, error) {
+	if caBundle == "" {
+		return nil, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	data, err := os.ReadFile(caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the CA bundle: %w", err)
+	}
+
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates were found in %s", caBundle)
+	}
+
+	return pool, nil
+}
+
+func parseDuration(value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,211 @@
+// Package opml reads and writes subscription lists in the Outline
+// Processor Markup Language (OPML).
+package opml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// CategorySeparator separates the names of nested folders in the
+// category of a subscription.
+const CategorySeparator = "/"
+
+// Subscription is a feed listed in an OPML document. Category is the
+// path of the folders that contain the feed, joined with
+// CategorySeparator, and is empty if the feed is not in a folder.
+type Subscription struct {
+	Title    string
+	XMLURL   string
+	HTMLURL  string
+	Category string
+}
+
+type document struct {
+	XMLName xml.Name   `xml:"opml"`
+	Version string     `xml:"version,attr"`
+	Head    head       `xml:"head"`
+	Body    []*outline `xml:"body>outline"`
+}
+
+type head struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+// outline is an outline element. The attributes are decoded generically
+// because the capitalisation of their names varies between the
+// applications that produce OPML files.
+type outline struct {
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Outlines []*outline `xml:"outline"`
+}
+
+func (o outline) attr(name string) string {
+	for _, attr := range o.Attrs {
+		if strings.EqualFold(attr.Name.Local, name) {
+			return strings.TrimSpace(attr.Value)
+		}
+	}
+
+	return ""
+}
+
+// Parse reads the subscriptions from an OPML 1.0 or 2.0 document.
+// Outlines without a feed URL are treated as folders.
+func Parse(reader io.Reader) ([]Subscription, error) {
+	var doc document
+
+	if err := xml.NewDecoder(reader).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to decode the OPML document: %w", err)
+	}
+
+	var subscriptions []Subscription
+
+	for _, outline := range doc.Body {
+		subscriptions = appendSubscriptions(subscriptions, outline, nil)
+	}
+
+	return subscriptions, nil
+}
+
+func appendSubscriptions(subscriptions []Subscription, outline *outline, folders []string) []Subscription {
+	title := outline.attr("title")
+	if title == "" {
+		title = outline.attr("text")
+	}
+
+	xmlURL := outline.attr("xmlUrl")
+
+	if xmlURL == "" {
+		if title != "" {
+			folders = append(folders[:len(folders):len(folders)], title)
+		}
+
+		for _, child := range outline.Outlines {
+			subscriptions = appendSubscriptions(subscriptions, child, folders)
+		}
+
+		return subscriptions
+	}
+
+	category := strings.Join(folders, CategorySeparator)
+	if category == "" {
+		category = firstCategory(outline.attr("category"))
+	}
+
+	return append(subscriptions, Subscription{
+		Title:    title,
+		XMLURL:   xmlURL,
+		HTMLURL:  outline.attr("htmlUrl"),
+		Category: category,
+	})
+}
+
+// firstCategory returns the first category listed in the category
+// attribute of OPML 2.0, which is a comma separated list of slash
+// delimited paths.
+func firstCategory(value string) string {
+	first, _, _ := strings.Cut(value, ",")
+
+	return strings.Trim(strings.TrimSpace(first), CategorySeparator)
+}
+
+// Write writes the subscriptions as an OPML 2.0 document. Subscriptions
+// with a category are placed in nested folders named after the parts of
+// the category.
+func Write(writer io.Writer, title string, subscriptions []Subscription) error {
+	doc := document{
+		Version: "2.0",
+		Head: head{
+			Title:       title,
+			DateCreated: time.Now().UTC().Format(time.RFC1123Z),
+		},
+	}
+
+	folders := make(map[string]*outline)
+
+	for _, subscription := range subscriptions {
+		feed := &outline{
+			Attrs: []xml.Attr{
+				attr("type", "rss"),
+				attr("text", subscription.Title),
+				attr("title", subscription.Title),
+				attr("xmlUrl", subscription.XMLURL),
+			},
+		}
+
+		if subscription.HTMLURL != "" {
+			feed.Attrs = append(feed.Attrs, attr("htmlUrl", subscription.HTMLURL))
+		}
+
+		if subscription.Category == "" {
+			doc.Body = append(doc.Body, feed)
+
+			continue
+		}
+
+		parent := folder(&doc, folders, subscription.Category)
+		parent.Outlines = append(parent.Outlines, feed)
+	}
+
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return fmt.Errorf("unable to write the XML header: %w", err)
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("unable to encode the OPML document: %w", err)
+	}
+
+	if _, err := io.WriteString(writer, "\n"); err != nil {
+		return fmt.Errorf("unable to write the OPML document: %w", err)
+	}
+
+	return nil
+}
+
+// folder returns the outline of the folder for the category, creating
+// it and its parent folders if they do not exist yet.
+func folder(doc *document, folders map[string]*outline, category string) *outline {
+	if existing, ok := folders[category]; ok {
+		return existing
+	}
+
+	created := &outline{}
+
+	parentCategory, name, nested := cutLast(category, CategorySeparator)
+	if nested {
+		parent := folder(doc, folders, parentCategory)
+		parent.Outlines = append(parent.Outlines, created)
+	} else {
+		doc.Body = append(doc.Body, created)
+	}
+
+	created.Attrs = []xml.Attr{
+		attr("text", name),
+		attr("title", name),
+	}
+
+	folders[category] = created
+
+	return created
+}
+
+func cutLast(value, separator string) (string, string, bool) {
+	ind := strings.LastIndex(value, separator)
+	if ind == -1 {
+		return "", value, false
+	}
+
+	return value[:ind], value[ind+len(separator):], true
+}
+
+func attr(name, value string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Local: name}, Value: value}
+}
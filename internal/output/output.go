@@ -0,0 +1,199 @@
+// Package output renders the results of the listing commands as text
+// for people or as JSON, CSV or TSV for scripts.
+//
+// The machine-readable formats are generated from a slice of structs.
+// JSON uses the encoding/json rules for the struct. CSV and TSV have a
+// header row with the JSON names of the fields followed by one row per
+// struct, so the columns match the keys of the JSON objects.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	Text Format = "text"
+	JSON Format = "json"
+	CSV  Format = "csv"
+	TSV  Format = "tsv"
+)
+
+// Formats is the list of the supported output formats.
+var Formats = []Format{Text, JSON, CSV, TSV}
+
+// ParseFormat returns the output format with the given name. An empty
+// name is the text format.
+func ParseFormat(name string) (Format, error) {
+	if name == "" {
+		return Text, nil
+	}
+
+	for _, format := range Formats {
+		if strings.EqualFold(name, string(format)) {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unrecognised output format %q", name)
+}
+
+// Render writes the records in the given format. Records must be a
+// slice of structs. The text function is called to write the text
+// format.
+func Render(writer io.Writer, format Format, records any, text func(io.Writer) error) error {
+	switch format {
+	case Text, "":
+		return text(writer)
+	case JSON:
+		return renderJSON(writer, records)
+	case CSV:
+		return renderCSV(writer, records)
+	case TSV:
+		return renderTSV(writer, records)
+	default:
+		return fmt.Errorf("unrecognised output format %q", format)
+	}
+}
+
+func renderJSON(writer io.Writer, records any) error {
+	value := reflect.ValueOf(records)
+
+	// A nil slice is encoded as an empty array so that the output is
+	// always an array.
+	if value.Kind() == reflect.Slice && value.IsNil() {
+		records = reflect.MakeSlice(value.Type(), 0, 0).Interface()
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(records); err != nil {
+		return fmt.Errorf("unable to encode the JSON output: %w", err)
+	}
+
+	return nil
+}
+
+func renderCSV(writer io.Writer, records any) error {
+	header, rows, err := table(records)
+	if err != nil {
+		return err
+	}
+
+	csvWriter := csv.NewWriter(writer)
+
+	if err := csvWriter.Write(header); err != nil {
+		return fmt.Errorf("unable to write the CSV header: %w", err)
+	}
+
+	if err := csvWriter.WriteAll(rows); err != nil {
+		return fmt.Errorf("unable to write the CSV rows: %w", err)
+	}
+
+	return nil
+}
+
+// tsvReplacer replaces the characters that would break the structure of
+// a TSV row. TSV has no quoting so the values are written as they are
+// otherwise.
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+func renderTSV(writer io.Writer, records any) error {
+	header, rows, err := table(records)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range append([][]string{header}, rows...) {
+		for ind := range row {
+			row[ind] = tsvReplacer.Replace(row[ind])
+		}
+
+		if _, err := io.WriteString(writer, strings.Join(row, "\t")+"\n"); err != nil {
+			return fmt.Errorf("unable to write the TSV output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// table converts the slice of structs into a header and rows of
+// formatted values.
+func table(records any) ([]string, [][]string, error) {
+	value := reflect.ValueOf(records)
+	if value.Kind() != reflect.Slice || value.Type().Elem().Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("unable to render %T as a table", records)
+	}
+
+	recordType := value.Type().Elem()
+
+	var (
+		header  []string
+		indexes []int
+	)
+
+	for ind := range recordType.NumField() {
+		field := recordType.Field(ind)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+
+		header = append(header, name)
+		indexes = append(indexes, ind)
+	}
+
+	rows := make([][]string, value.Len())
+
+	for row := range value.Len() {
+		record := value.Index(row)
+		rows[row] = make([]string, len(indexes))
+
+		for column, ind := range indexes {
+			rows[row][column] = formatValue(record.Field(ind))
+		}
+	}
+
+	return header, rows, nil
+}
+
+func formatValue(value reflect.Value) string {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ""
+		}
+
+		value = value.Elem()
+	}
+
+	switch typed := value.Interface().(type) {
+	case time.Time:
+		if typed.IsZero() {
+			return ""
+		}
+
+		return typed.Format(time.RFC3339)
+	case []string:
+		return strings.Join(typed, ",")
+	case fmt.Stringer:
+		return typed.String()
+	default:
+		return fmt.Sprint(typed)
+	}
+}
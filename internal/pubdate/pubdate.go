@@ -0,0 +1,268 @@
+// Package pubdate parses the publication dates found in feeds.
+//
+// Feeds in the wild rarely follow the date formats required by their
+// specifications so Parse tries a broad set of layouts, tolerates
+// malformed day names and converts named time zones to numeric offsets
+// before parsing.
+package pubdate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Source describes where the publication date of a post came from.
+type Source string
+
+const (
+	// SourceItem is used when the date was parsed from the item itself.
+	SourceItem Source = "item"
+
+	// SourceFeed is used when the date was taken from the last build
+	// date of the feed.
+	SourceFeed Source = "feed"
+
+	// SourceFetch is used when the date is the time the feed was fetched.
+	SourceFetch Source = "fetch"
+)
+
+var ErrEmptyDate = errors.New("the date is empty")
+
+// layouts is the list of layouts that Parse will try in order. Named
+// time zones are converted to numeric offsets before parsing so none of
+// the layouts use the MST placeholder. Layouts without a time zone are
+// parsed as UTC.
+var layouts = []string{
+	// RFC 822 and RFC 1123 variants without the day name.
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 Z07:00",
+	"2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04 Z07:00",
+	"2 Jan 06 15:04:05 -0700",
+	"2 Jan 06 15:04 -0700",
+	"2 January 2006 15:04:05 -0700",
+	"2 January 2006 15:04 -0700",
+	"2 Jan 2006 15:04:05",
+	"2 Jan 2006 15:04",
+	"2 January 2006 15:04:05",
+	"2 Jan 2006",
+	"2 January 2006",
+
+	// US style dates.
+	"Jan 2 2006 15:04:05 -0700",
+	"Jan 2, 2006 15:04:05 -0700",
+	"Jan 2 2006 15:04:05",
+	"Jan 2, 2006 15:04:05",
+	"January 2, 2006 15:04:05",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"Jan 2 15:04:05 2006",
+	"Jan 2, 2006 3:04:05 PM -0700",
+	"Jan 2, 2006 3:04 PM -0700",
+	"Jan 2, 2006 3:04:05 PM",
+	"Jan 2, 2006 3:04 PM",
+	"January 2, 2006 3:04:05 PM",
+	"January 2, 2006 3:04 PM",
+
+	// ISO 8601 and RFC 3339 variants.
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999-0700",
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04-0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05.999999999 -0700",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 Z07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+}
+
+// zoneOffsets maps the time zone abbreviations commonly found in feeds
+// to their UTC offsets.
+var zoneOffsets = map[string]string{
+	"UT":   "+0000",
+	"UTC":  "+0000",
+	"GMT":  "+0000",
+	"Z":    "+0000",
+	"WET":  "+0000",
+	"WEST": "+0100",
+	"BST":  "+0100",
+	"IST":  "+0530",
+	"CET":  "+0100",
+	"CEST": "+0200",
+	"MET":  "+0100",
+	"MEST": "+0200",
+	"EET":  "+0200",
+	"EEST": "+0300",
+	"MSK":  "+0300",
+	"EST":  "-0500",
+	"EDT":  "-0400",
+	"CST":  "-0600",
+	"CDT":  "-0500",
+	"MST":  "-0700",
+	"MDT":  "-0600",
+	"PST":  "-0800",
+	"PDT":  "-0700",
+	"AKST": "-0900",
+	"AKDT": "-0800",
+	"HST":  "-1000",
+	"AST":  "-0400",
+	"ADT":  "-0300",
+	"NST":  "-0330",
+	"NDT":  "-0230",
+	"JST":  "+0900",
+	"KST":  "+0900",
+	"HKT":  "+0800",
+	"SGT":  "+0800",
+	"AWST": "+0800",
+	"ACST": "+0930",
+	"ACDT": "+1030",
+	"AEST": "+1000",
+	"AEDT": "+1100",
+	"NZST": "+1200",
+	"NZDT": "+1300",
+}
+
+var (
+	// dayNamePrefix matches a leading day name such as "Mon, ",
+	// "Tues " or "Thursday,".
+	dayNamePrefix = regexp.MustCompile(`^[A-Za-z]+\.?,?\s+`)
+
+	// relativeZone matches zones written relative to UTC or GMT such
+	// as "GMT+1", "UTC-05:30" or "GMT+0100".
+	relativeZone = regexp.MustCompile(`(?i)\b(?:GMT|UTC|UT)([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+	// namedZone matches a trailing time zone abbreviation optionally
+	// followed by a parenthesised comment, e.g. "EST" or "+0000 (UTC)".
+	namedZone = regexp.MustCompile(`\s*\(?\b([A-Za-z]{1,5})\)?$`)
+
+	whitespace = regexp.MustCompile(`\s+`)
+)
+
+// Parse parses the publication date of a feed or feed item.
+func Parse(value string) (time.Time, error) {
+	normalised := normalise(value)
+	if normalised == "" {
+		return time.Time{}, ErrEmptyDate
+	}
+
+	for _, layout := range layouts {
+		parsed, err := time.Parse(layout, normalised)
+		if err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognised date format: %q", value)
+}
+
+// Resolve returns the publication date of an item along with the
+// source of the date. The date of the item is used when it can be
+// parsed, followed by the last build date of the feed and finally the
+// time the feed was fetched. The date is always returned in UTC.
+func Resolve(itemDate, feedDate string, fetchedAt time.Time) (time.Time, Source) {
+	if parsed, err := Parse(itemDate); err == nil {
+		return parsed.UTC(), SourceItem
+	}
+
+	if parsed, err := Parse(feedDate); err == nil {
+		return parsed.UTC(), SourceFeed
+	}
+
+	return fetchedAt.UTC(), SourceFetch
+}
+
+// normalise prepares the date for parsing by collapsing whitespace,
+// removing the day name and converting the time zone to a numeric
+// offset.
+func normalise(value string) string {
+	value = strings.TrimSpace(whitespace.ReplaceAllString(value, " "))
+	if value == "" {
+		return ""
+	}
+
+	// Day names are frequently abbreviated incorrectly (e.g. "Tues")
+	// and add no information so they are removed. ISO 8601 dates and
+	// US style dates begin with a digit or a month name respectively
+	// and are left as they are.
+	if loc := dayNamePrefix.FindStringIndex(value); loc != nil {
+		word := strings.TrimRight(value[:loc[1]], " ,.")
+		if !isMonth(word) {
+			value = value[loc[1]:]
+		}
+	}
+
+	if match := relativeZone.FindStringSubmatchIndex(value); match != nil {
+		sign := value[match[2]:match[3]]
+		hours, _ := strconv.Atoi(value[match[4]:match[5]])
+
+		minutes := 0
+		if match[6] != -1 {
+			minutes, _ = strconv.Atoi(value[match[6]:match[7]])
+		}
+
+		value = strings.TrimSpace(value[:match[0]]) + fmt.Sprintf(" %s%02d%02d", sign, hours, minutes)
+
+		return value
+	}
+
+	if match := namedZone.FindStringSubmatchIndex(value); match != nil {
+		abbreviation := strings.ToUpper(value[match[2]:match[3]])
+
+		// Avoid treating the month or the AM/PM marker in a date
+		// without a time zone as a time zone.
+		if isMonth(abbreviation) || abbreviation == "AM" || abbreviation == "PM" {
+			return value
+		}
+
+		offset, ok := zoneOffsets[abbreviation]
+		if !ok {
+			// Unknown abbreviations are treated as UTC which
+			// matches the behaviour of the time package.
+			offset = "+0000"
+		}
+
+		prefix := strings.TrimSpace(value[:match[0]])
+
+		// A parenthesised abbreviation after a numeric offset is
+		// only a comment so the offset is kept.
+		if hasNumericOffset(prefix) {
+			return prefix
+		}
+
+		return prefix + " " + offset
+	}
+
+	return value
+}
+
+var numericOffset = regexp.MustCompile(`[+-]\d{2}:?\d{2}$`)
+
+func hasNumericOffset(value string) bool {
+	return numericOffset.MatchString(value)
+}
+
+func isMonth(word string) bool {
+	if len(word) < 3 {
+		return false
+	}
+
+	for month := time.January; month <= time.December; month++ {
+		if strings.HasPrefix(strings.ToLower(month.String()), strings.ToLower(word)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,201 @@
+package pubdate
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNormalise(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "day name is removed",
+			value: "Tues, 04 Jun 2024 10:00:00 GMT",
+			want:  "04 Jun 2024 10:00:00 +0000",
+		},
+		{
+			name:  "whitespace is collapsed",
+			value: "  04  Jun 2024\t10:00 EST ",
+			want:  "04 Jun 2024 10:00 -0500",
+		},
+		{
+			name:  "relative zone",
+			value: "04 Jun 2024 10:00:00 GMT+1",
+			want:  "04 Jun 2024 10:00:00 +0100",
+		},
+		{
+			name:  "relative zone with minutes",
+			value: "04 Jun 2024 10:00:00 UTC-05:30",
+			want:  "04 Jun 2024 10:00:00 -0530",
+		},
+		{
+			name:  "comment after a numeric offset",
+			value: "04 Jun 2024 10:00:00 +0200 (CEST)",
+			want:  "04 Jun 2024 10:00:00 +0200",
+		},
+		{
+			name:  "unknown zone is UTC",
+			value: "04 Jun 2024 10:00:00 XYZ",
+			want:  "04 Jun 2024 10:00:00 +0000",
+		},
+		{
+			name:  "month name is kept",
+			value: "June 5, 2024",
+			want:  "June 5, 2024",
+		},
+		{
+			name:  "AM is not a time zone",
+			value: "Jun 5, 2024 10:00 AM",
+			want:  "Jun 5, 2024 10:00 AM",
+		},
+		{
+			name:  "empty",
+			value: " \t ",
+			want:  "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := normalise(tc.value); got != tc.want {
+				t.Errorf("normalise(%q) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		value string
+		want  time.Time
+	}{
+		{
+			name:  "RFC 1123",
+			value: "Wed, 05 Jun 2024 10:00:00 GMT",
+			want:  time.Date(2024, time.June, 5, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "named zone",
+			value: "Wed, 05 Jun 2024 10:00:00 PDT",
+			want:  time.Date(2024, time.June, 5, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "two digit year",
+			value: "5 Jun 24 10:00:00 +0000",
+			want:  time.Date(2024, time.June, 5, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "RFC 3339",
+			value: "2024-06-05T10:00:00+02:00",
+			want:  time.Date(2024, time.June, 5, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "ISO 8601 without a zone",
+			value: "2024-06-05 10:00:00",
+			want:  time.Date(2024, time.June, 5, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "US style date",
+			value: "June 5, 2024",
+			want:  time.Date(2024, time.June, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "US style date with a 12 hour clock",
+			value: "Jun 5, 2024 10:00 AM",
+			want:  time.Date(2024, time.June, 5, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "US style date with a 12 hour clock and a zone",
+			value: "Jun 5, 2024 3:30:15 PM EST",
+			want:  time.Date(2024, time.June, 5, 20, 30, 15, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse(tc.value)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned an error: %v", tc.value, err)
+			}
+
+			if !got.Equal(tc.want) {
+				t.Errorf("Parse(%q) = %s, want %s", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Parse(""); !errors.Is(err, ErrEmptyDate) {
+		t.Errorf("Parse(\"\") returned %v, want %v", err, ErrEmptyDate)
+	}
+
+	if _, err := Parse("not a date"); err == nil {
+		t.Error("Parse(\"not a date\") did not return an error")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	fetchedAt := time.Date(2024, time.June, 6, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	testCases := []struct {
+		name       string
+		itemDate   string
+		feedDate   string
+		want       time.Time
+		wantSource Source
+	}{
+		{
+			name:       "item date",
+			itemDate:   "2024-06-05T10:00:00+02:00",
+			feedDate:   "2024-06-04T10:00:00Z",
+			want:       time.Date(2024, time.June, 5, 8, 0, 0, 0, time.UTC),
+			wantSource: SourceItem,
+		},
+		{
+			name:       "feed date",
+			itemDate:   "yesterday",
+			feedDate:   "2024-06-04T10:00:00-04:00",
+			want:       time.Date(2024, time.June, 4, 14, 0, 0, 0, time.UTC),
+			wantSource: SourceFeed,
+		},
+		{
+			name:       "fetch time",
+			want:       time.Date(2024, time.June, 6, 10, 0, 0, 0, time.UTC),
+			wantSource: SourceFetch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, source := Resolve(tc.itemDate, tc.feedDate, fetchedAt)
+
+			if source != tc.wantSource {
+				t.Errorf("Resolve() source = %q, want %q", source, tc.wantSource)
+			}
+
+			if !got.Equal(tc.want) || got.Location() != time.UTC {
+				t.Errorf("Resolve() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package rss
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    atomText    `xml:"title"`
+	Subtitle atomText    `xml:"subtitle"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+	syndicationModule
+}
+
+type atomEntry struct {
+	ID        string       `xml:"id"`
+	Title     atomText     `xml:"title"`
+	Links     []atomLink   `xml:"link"`
+	Published string       `xml:"published"`
+	Updated   string       `xml:"updated"`
+	Summary   atomText     `xml:"summary"`
+	Content   atomText     `xml:"content"`
+	Authors   []atomPerson `xml:"author"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// atomText is an Atom text construct. The body of an XHTML text
+// construct is made up of child elements so the raw inner XML is
+// kept for that case.
+type atomText struct {
+	Type     string `xml:"type,attr"`
+	Text     string `xml:",chardata"`
+	InnerXML string `xml:",innerxml"`
+}
+
+func (t atomText) value() string {
+	if t.Type == "xhtml" {
+		return strings.TrimSpace(t.InnerXML)
+	}
+
+	return strings.TrimSpace(t.Text)
+}
+
+func parseAtom(data []byte) (*Feed, error) {
+	var doc atomFeed
+
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf(
+			"unable to decode the Atom document: %w",
+			err,
+		)
+	}
+
+	feed := Feed{
+		Title:         doc.Title.value(),
+		Link:          alternateLink(doc.Links),
+		Description:   doc.Subtitle.value(),
+		LastBuildDate: strings.TrimSpace(doc.Updated),
+		Hints: FetchHints{
+			UpdatePeriod: doc.updatePeriod(),
+		},
+		Items: make([]Item, len(doc.Entries)),
+	}
+
+	for ind, entry := range doc.Entries {
+		description := entry.Summary.value()
+		if description == "" {
+			description = entry.Content.value()
+		}
+
+		pubDate := entry.Published
+		if pubDate == "" {
+			pubDate = entry.Updated
+		}
+
+		feed.Items[ind] = Item{
+			GUID:        strings.TrimSpace(entry.ID),
+			Title:       entry.Title.value(),
+			Link:        alternateLink(entry.Links),
+			Description: description,
+			PubDate:     strings.TrimSpace(pubDate),
+			Authors:     make([]string, 0, len(entry.Authors)),
+		}
+
+		for _, author := range entry.Authors {
+			if name := strings.TrimSpace(author.Name); name != "" {
+				feed.Items[ind].Authors = append(feed.Items[ind].Authors, name)
+			}
+		}
+	}
+
+	return &feed, nil
+}
+
+// alternateLink returns the URL of the alternate representation
+// of the feed or entry. A link without a rel attribute is an
+// alternate link as per RFC 4287. HTML links are preferred and the
+// first link is used when no alternate link is present.
+func alternateLink(links []atomLink) string {
+	var alternate string
+
+	for _, link := range links {
+		if link.Rel != "" && link.Rel != "alternate" {
+			continue
+		}
+
+		if link.Type == "" || link.Type == "text/html" {
+			return link.Href
+		}
+
+		if alternate == "" {
+			alternate = link.Href
+		}
+	}
+
+	if alternate == "" && len(links) > 0 {
+		alternate = links[0].Href
+	}
+
+	return alternate
+}
@@ -0,0 +1,121 @@
+package rss
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// xmlEncodingDecl matches the encoding declaration in the XML prolog.
+var xmlEncodingDecl = regexp.MustCompile(`^(<\?xml[^>]*?encoding\s*=\s*["'])([A-Za-z0-9._:-]+)(["'])`)
+
+// charsetTables maps the normalised names and aliases of the supported
+// single-byte character sets to their decoding tables. ISO-8859-1 is
+// handled separately as its code points are identical to its bytes.
+var charsetTables = map[string]*[128]rune{
+	"iso88592":    &iso88592Table,
+	"latin2":      &iso88592Table,
+	"l2":          &iso88592Table,
+	"iso885915":   &iso885915Table,
+	"latin9":      &iso885915Table,
+	"windows1250": &windows1250Table,
+	"cp1250":      &windows1250Table,
+	"windows1251": &windows1251Table,
+	"cp1251":      &windows1251Table,
+	"windows1252": &windows1252Table,
+	"cp1252":      &windows1252Table,
+	"koi8r":       &koi8rTable,
+	"koi8":        &koi8rTable,
+	"koi8u":       &koi8uTable,
+}
+
+// toUTF8 transcodes an XML document to UTF-8. The character set is
+// taken from the Content-Type header if present, otherwise from the
+// encoding declaration in the XML prolog, as per RFC 7303. The encoding
+// declaration is rewritten after transcoding so that the XML decoder
+// does not attempt to decode the document a second time.
+func toUTF8(data []byte, contentType string) ([]byte, error) {
+	data = bytes.TrimPrefix(data, byteOrderMark)
+
+	label := ""
+
+	if _, params, err := mime.ParseMediaType(contentType); err == nil {
+		label = params["charset"]
+	}
+
+	match := xmlEncodingDecl.FindSubmatchIndex(data)
+
+	if label == "" && match != nil {
+		label = string(data[match[4]:match[5]])
+	}
+
+	decoded, err := decode(data, label)
+	if err != nil {
+		return nil, err
+	}
+
+	// The prolog is plain ASCII in every supported character set so
+	// its position is unchanged by the transcoding.
+	if match != nil {
+		decoded = append(
+			append([]byte(nil), decoded[:match[4]]...),
+			append([]byte("UTF-8"), decoded[match[5]:]...)...,
+		)
+	}
+
+	return decoded, nil
+}
+
+func decode(data []byte, label string) ([]byte, error) {
+	name := normaliseCharset(label)
+
+	switch name {
+	case "", "utf8", "usascii", "ascii":
+		return data, nil
+	case "iso88591", "latin1", "l1", "iso8859":
+		return decodeSingleByte(data, nil), nil
+	}
+
+	table, ok := charsetTables[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported character set %q", label)
+	}
+
+	return decodeSingleByte(data, table), nil
+}
+
+// decodeSingleByte decodes text in a single-byte character set. A nil
+// table decodes ISO-8859-1.
+func decodeSingleByte(data []byte, table *[128]rune) []byte {
+	output := make([]byte, 0, len(data)+len(data)/4)
+
+	for _, b := range data {
+		switch {
+		case b < utf8.RuneSelf:
+			output = append(output, b)
+		case table == nil:
+			output = utf8.AppendRune(output, rune(b))
+		default:
+			output = utf8.AppendRune(output, table[b-utf8.RuneSelf])
+		}
+	}
+
+	return output
+}
+
+// normaliseCharset lowercases the name of the character set and
+// removes the punctuation so that aliases such as "ISO_8859-1" and
+// "iso-8859-1" compare equal.
+func normaliseCharset(label string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '.', ':', ' ':
+			return -1
+		}
+
+		return r
+	}, strings.ToLower(strings.TrimSpace(label)))
+}
@@ -0,0 +1,262 @@
+package rss
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/httpclient"
+)
+
+const discoveryAcceptHeader = "text/html, application/xhtml+xml, " + acceptHeader
+
+// feedMediaTypes are the media types of the alternate links that point
+// to feeds.
+var feedMediaTypes = map[string]bool{
+	"application/rss+xml":   true,
+	"application/atom+xml":  true,
+	"application/rdf+xml":   true,
+	"application/feed+json": true,
+}
+
+// commonFeedPaths are the paths that are probed when a page does not
+// link to its feeds.
+var commonFeedPaths = []string{
+	"/feed",
+	"/feed/",
+	"/rss",
+	"/rss.xml",
+	"/atom.xml",
+	"/feed.xml",
+	"/index.xml",
+	"/feed.json",
+}
+
+var (
+	htmlComment   = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlLinkTag   = regexp.MustCompile(`(?i)<link\b[^>]*>`)
+	htmlBaseTag   = regexp.MustCompile(`(?i)<base\b[^>]*>`)
+	htmlAttribute = regexp.MustCompile(`([A-Za-z_:][-A-Za-z0-9_:.]*)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+// Candidate is a feed found by Discover.
+type Candidate struct {
+	URL   string
+	Title string
+	Type  string
+}
+
+// Discover finds the feeds published by a website. If the URL is
+// already a feed then it is returned as the only candidate. Otherwise
+// the page is searched for alternate links to feeds and, failing that,
+// the common feed paths of the website are probed.
+func Discover(ctx context.Context, client *httpclient.Client, pageURL string) ([]Candidate, error) {
+	page, err := fetchDocument(ctx, client, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if feed, err := Parse(page.data, page.contentType); err == nil {
+		return []Candidate{page.candidate(feed)}, nil
+	}
+
+	candidates := findFeedLinks(page.data, page.contentType, page.url)
+	if len(candidates) > 0 {
+		return candidates, nil
+	}
+
+	seen := make(map[string]bool)
+
+	for _, path := range commonFeedPaths {
+		probeURL := page.url.ResolveReference(&url.URL{Path: path})
+
+		probed, err := fetchDocument(ctx, client, probeURL.String())
+		if err != nil {
+			continue
+		}
+
+		feed, err := Parse(probed.data, probed.contentType)
+		if err != nil {
+			continue
+		}
+
+		candidate := probed.candidate(feed)
+
+		if seen[candidate.URL] {
+			continue
+		}
+
+		seen[candidate.URL] = true
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+type document struct {
+	url         *url.URL
+	contentType string
+	data        []byte
+}
+
+// candidate returns the document as a discovered feed.
+func (d document) candidate(feed *Feed) Candidate {
+	mediaType, _, _ := mime.ParseMediaType(d.contentType)
+
+	return Candidate{
+		URL:   d.url.String(),
+		Title: feed.Title,
+		Type:  mediaType,
+	}
+}
+
+// fetchDocument downloads the document at the given URL. The URL of the
+// returned document is the URL reached after following any redirects.
+func fetchDocument(ctx context.Context, client *httpclient.Client, documentURL string) (document, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, documentURL, nil)
+	if err != nil {
+		return document{}, fmt.Errorf("received an error creating the HTTP request: %w", err)
+	}
+
+	request.Header.Set("Accept", discoveryAcceptHeader)
+
+	response, err := client.HTTPClient.Do(request)
+	if err != nil {
+		return document{}, fmt.Errorf("error getting the response from the server: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return document{}, fmt.Errorf(
+			"received a bad status from %s: (%d) %s",
+			documentURL,
+			response.StatusCode,
+			response.Status,
+		)
+	}
+
+	data, err := client.ReadBody(response)
+	if err != nil {
+		return document{}, fmt.Errorf("unable to read the response from the server: %w", err)
+	}
+
+	return document{
+		url:         response.Request.URL,
+		contentType: response.Header.Get("Content-Type"),
+		data:        data,
+	}, nil
+}
+
+// findFeedLinks returns the feeds listed in the alternate links of a
+// HTML page. Relative links are resolved against the base URL of the
+// page.
+func findFeedLinks(data []byte, contentType string, pageURL *url.URL) []Candidate {
+	if _, params, err := mime.ParseMediaType(contentType); err == nil {
+		if decoded, err := decode(data, params["charset"]); err == nil {
+			data = decoded
+		}
+	}
+
+	data = htmlComment.ReplaceAll(data, nil)
+
+	// The links are only expected in the head of the page.
+	if end := bytes.Index(bytes.ToLower(data), []byte("</head>")); end != -1 {
+		data = data[:end]
+	}
+
+	base := pageURL
+
+	if tag := htmlBaseTag.Find(data); tag != nil {
+		if href, ok := htmlAttributes(tag)["href"]; ok {
+			if resolved, err := pageURL.Parse(href); err == nil {
+				base = resolved
+			}
+		}
+	}
+
+	var candidates []Candidate
+
+	seen := make(map[string]bool)
+
+	for _, tag := range htmlLinkTag.FindAll(data, -1) {
+		attributes := htmlAttributes(tag)
+
+		if !hasToken(attributes["rel"], "alternate") {
+			continue
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(attributes["type"])
+		if !feedMediaTypes[mediaType] {
+			continue
+		}
+
+		href := strings.TrimSpace(attributes["href"])
+		if href == "" {
+			continue
+		}
+
+		resolved, err := base.Parse(href)
+		if err != nil {
+			continue
+		}
+
+		if seen[resolved.String()] {
+			continue
+		}
+
+		seen[resolved.String()] = true
+
+		candidates = append(candidates, Candidate{
+			URL:   resolved.String(),
+			Title: attributes["title"],
+			Type:  mediaType,
+		})
+	}
+
+	return candidates
+}
+
+// htmlAttributes returns the attributes of a HTML tag keyed by their
+// lower case names.
+func htmlAttributes(tag []byte) map[string]string {
+	attributes := make(map[string]string)
+
+	for _, match := range htmlAttribute.FindAllSubmatch(tag, -1) {
+		name := strings.ToLower(string(match[1]))
+		if _, ok := attributes[name]; ok {
+			continue
+		}
+
+		value := match[2]
+
+		switch {
+		case match[3] != nil:
+			value = match[3]
+		case match[4] != nil:
+			value = match[4]
+		}
+
+		attributes[name] = html.UnescapeString(string(value))
+	}
+
+	return attributes
+}
+
+// hasToken reports whether the space separated list contains the
+// token, ignoring case.
+func hasToken(list, token string) bool {
+	for _, field := range strings.Fields(list) {
+		if strings.EqualFold(field, token) {
+			return true
+		}
+	}
+
+	return false
+}
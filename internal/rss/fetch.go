@@ -0,0 +1,170 @@
+package rss
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/httpclient"
+)
+
+const acceptHeader = "application/rss+xml, application/atom+xml, application/feed+json, " +
+	"application/xml;q=0.9, application/json;q=0.8, */*;q=0.5"
+
+// CacheValidators are the HTTP cache validators of a previous response.
+// They are sent with the next request so that the server can respond
+// with 304 Not Modified if the feed has not changed.
+type CacheValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// FetchResult is the result of fetching a feed. Feed is nil if the
+// server reported that the feed has not been modified. The status code
+// and the size of the body are set whenever a response is received,
+// even if the fetch then fails.
+//
+// Redirects is the chain of redirects that was followed to reach the
+// feed. PermanentURL is the new location of the feed if the request was
+// permanently redirected, and is empty otherwise.
+type FetchResult struct {
+	Feed         *Feed
+	NotModified  bool
+	Validators   CacheValidators
+	StatusCode   int
+	Bytes        int
+	Redirects    []Redirect
+	PermanentURL string
+}
+
+// Redirect is a single redirect followed while fetching a feed.
+type Redirect struct {
+	From       string
+	To         string
+	StatusCode int
+}
+
+const maxRedirects = 10
+
+func FetchFeed(
+	ctx context.Context,
+	client *httpclient.Client,
+	url string,
+	validators CacheValidators,
+) (FetchResult, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return FetchResult{}, fmt.Errorf("received an error creating the HTTP request: %w", err)
+	}
+
+	request.Header.Set("Accept", acceptHeader)
+
+	if validators.ETag != "" {
+		request.Header.Set("If-None-Match", validators.ETag)
+	}
+
+	if validators.LastModified != "" {
+		request.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+
+	var redirects []Redirect
+
+	// The client is copied so that the redirects can be tracked
+	// without affecting other requests.
+	httpClient := *client.HTTPClient
+	httpClient.CheckRedirect = func(request *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		redirects = append(redirects, Redirect{
+			From:       via[len(via)-1].URL.String(),
+			To:         request.URL.String(),
+			StatusCode: request.Response.StatusCode,
+		})
+
+		return nil
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return FetchResult{Redirects: redirects}, fmt.Errorf("error getting the response from the server: %w", err)
+	}
+	defer response.Body.Close()
+
+	result := FetchResult{
+		StatusCode:   response.StatusCode,
+		Redirects:    redirects,
+		PermanentURL: permanentURL(redirects),
+	}
+
+	if response.StatusCode == http.StatusNotModified {
+		// The server may send updated validators with the 304
+		// response, otherwise the previous ones remain valid.
+		result.NotModified = true
+		result.Validators = CacheValidators{
+			ETag:         headerOrDefault(response.Header, "ETag", validators.ETag),
+			LastModified: headerOrDefault(response.Header, "Last-Modified", validators.LastModified),
+		}
+
+		return result, nil
+	}
+
+	if response.StatusCode >= 400 {
+		return result, fmt.Errorf(
+			"received a bad status from %s: (%d) %s",
+			url,
+			response.StatusCode,
+			response.Status,
+		)
+	}
+
+	data, err := client.ReadBody(response)
+	result.Bytes = len(data)
+
+	if err != nil {
+		return result, fmt.Errorf(
+			"unable to read the response from the server: %w",
+			err,
+		)
+	}
+
+	feed, err := Parse(data, response.Header.Get("Content-Type"))
+	if err != nil {
+		return result, err
+	}
+
+	result.Feed = feed
+	result.Validators = CacheValidators{
+		ETag:         response.Header.Get("ETag"),
+		LastModified: response.Header.Get("Last-Modified"),
+	}
+
+	return result, nil
+}
+
+// permanentURL returns the URL reached by following the permanent
+// redirects at the start of the redirect chain. A temporary redirect
+// means that the URL before it is still the canonical location of the
+// feed.
+func permanentURL(redirects []Redirect) string {
+	var location string
+
+	for _, redirect := range redirects {
+		if redirect.StatusCode != http.StatusMovedPermanently && redirect.StatusCode != http.StatusPermanentRedirect {
+			break
+		}
+
+		location = redirect.To
+	}
+
+	return location
+}
+
+func headerOrDefault(header http.Header, key, defaultValue string) string {
+	if value := header.Get(key); value != "" {
+		return value
+	}
+
+	return defaultValue
+}
@@ -0,0 +1,103 @@
+package rss
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FetchHints are the hints that a publisher gives about how often the
+// feed should be fetched. A zero value means that no hint was given.
+type FetchHints struct {
+	// TTL is the number of minutes the feed can be cached for
+	// (from the RSS ttl element).
+	TTL time.Duration
+
+	// UpdatePeriod is the period between updates to the feed (from
+	// the sy:updatePeriod and sy:updateFrequency elements).
+	UpdatePeriod time.Duration
+
+	// SkipHours are the hours of the day (in GMT) in which the feed
+	// should not be fetched.
+	SkipHours []int
+
+	// SkipDays are the days of the week in which the feed should not
+	// be fetched.
+	SkipDays []time.Weekday
+}
+
+// syndicationModule holds the elements of the RSS 1.0 Syndication
+// module which is also commonly used in RSS 2.0 and Atom feeds.
+type syndicationModule struct {
+	UpdatePeriod    string `xml:"http://purl.org/rss/1.0/modules/syndication/ updatePeriod"`
+	UpdateFrequency string `xml:"http://purl.org/rss/1.0/modules/syndication/ updateFrequency"`
+}
+
+func (s syndicationModule) updatePeriod() time.Duration {
+	var period time.Duration
+
+	switch strings.ToLower(strings.TrimSpace(s.UpdatePeriod)) {
+	case "hourly":
+		period = time.Hour
+	case "daily":
+		period = 24 * time.Hour
+	case "weekly":
+		period = 7 * 24 * time.Hour
+	case "monthly":
+		period = 30 * 24 * time.Hour
+	case "yearly":
+		period = 365 * 24 * time.Hour
+	default:
+		return 0
+	}
+
+	// The frequency is the number of times the feed is updated
+	// within the period and defaults to 1.
+	frequency, err := strconv.Atoi(strings.TrimSpace(s.UpdateFrequency))
+	if err != nil || frequency < 1 {
+		frequency = 1
+	}
+
+	return period / time.Duration(frequency)
+}
+
+func parseTTL(value string) time.Duration {
+	minutes, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || minutes < 1 {
+		return 0
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
+
+func parseSkipHours(values []string) []int {
+	hours := make([]int, 0, len(values))
+
+	for _, value := range values {
+		hour, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || hour < 0 || hour > 24 {
+			continue
+		}
+
+		// Some publishers use 1-24 instead of 0-23.
+		hours = append(hours, hour%24)
+	}
+
+	return hours
+}
+
+func parseSkipDays(values []string) []time.Weekday {
+	days := make([]time.Weekday, 0, len(values))
+
+	for _, value := range values {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(strings.TrimSpace(value), day.String()) {
+				days = append(days, day)
+
+				break
+			}
+		}
+	}
+
+	return days
+}
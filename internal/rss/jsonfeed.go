@@ -0,0 +1,126 @@
+package rss
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const jsonFeedVersionPrefix = "https://jsonfeed.org/version/1"
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	Description string         `json:"description"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            jsonFeedID       `json:"id"`
+	URL           string           `json:"url"`
+	ExternalURL   string           `json:"external_url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	ContentText   string           `json:"content_text"`
+	Summary       string           `json:"summary"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Author        *jsonFeedAuthor  `json:"author"`
+	Authors       []jsonFeedAuthor `json:"authors"`
+}
+
+// jsonFeedID is the identifier of an item. The specification requires
+// a string but some publishers use numbers so both are accepted.
+type jsonFeedID string
+
+func (id *jsonFeedID) UnmarshalJSON(data []byte) error {
+	var value string
+
+	if err := json.Unmarshal(data, &value); err == nil {
+		*id = jsonFeedID(value)
+
+		return nil
+	}
+
+	var number json.Number
+
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("the item ID is neither a string nor a number: %w", err)
+	}
+
+	*id = jsonFeedID(number.String())
+
+	return nil
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+func parseJSONFeed(data []byte) (*Feed, error) {
+	var doc jsonFeed
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf(
+			"unable to decode the JSON Feed document: %w",
+			err,
+		)
+	}
+
+	if !strings.HasPrefix(doc.Version, jsonFeedVersionPrefix) {
+		return nil, fmt.Errorf("unsupported JSON Feed version %q", doc.Version)
+	}
+
+	feed := Feed{
+		Title:       doc.Title,
+		Link:        doc.HomePageURL,
+		Description: doc.Description,
+		Items:       make([]Item, len(doc.Items)),
+	}
+
+	for ind, item := range doc.Items {
+		link := item.URL
+		if link == "" {
+			link = item.ExternalURL
+		}
+
+		description := item.ContentHTML
+		if description == "" {
+			description = item.ContentText
+		}
+
+		if description == "" {
+			description = item.Summary
+		}
+
+		pubDate := item.DatePublished
+		if pubDate == "" {
+			pubDate = item.DateModified
+		}
+
+		// The author field was deprecated in JSON Feed 1.1 in
+		// favour of the authors field.
+		authors := item.Authors
+		if len(authors) == 0 && item.Author != nil {
+			authors = []jsonFeedAuthor{*item.Author}
+		}
+
+		feed.Items[ind] = Item{
+			GUID:        string(item.ID),
+			Title:       item.Title,
+			Link:        link,
+			Description: description,
+			PubDate:     pubDate,
+			Authors:     make([]string, 0, len(authors)),
+		}
+
+		for _, author := range authors {
+			if author.Name != "" {
+				feed.Items[ind].Authors = append(feed.Items[ind].Authors, author.Name)
+			}
+		}
+	}
+
+	return &feed, nil
+}
@@ -0,0 +1,76 @@
+package rss
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+const rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+// rdfFeed is an RSS 1.0 document. Unlike RSS 2.0 the items are
+// siblings of the channel element.
+type rdfFeed struct {
+	XMLName xml.Name   `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# RDF"`
+	Channel rdfChannel `xml:"channel"`
+	Items   []rdfItem  `xml:"item"`
+}
+
+type rdfChannel struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
+	syndicationModule
+}
+
+type rdfItem struct {
+	About       string `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Creator     string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+}
+
+func parseRDF(data []byte) (*Feed, error) {
+	var doc rdfFeed
+
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf(
+			"unable to decode the RDF document: %w",
+			err,
+		)
+	}
+
+	feed := Feed{
+		Title:         doc.Channel.Title,
+		Link:          doc.Channel.Link,
+		Description:   doc.Channel.Description,
+		LastBuildDate: doc.Channel.Date,
+		Hints: FetchHints{
+			UpdatePeriod: doc.Channel.updatePeriod(),
+		},
+		Items: make([]Item, len(doc.Items)),
+	}
+
+	for ind, item := range doc.Items {
+		link := item.Link
+		if link == "" {
+			link = item.About
+		}
+
+		feed.Items[ind] = Item{
+			GUID:        item.About,
+			Title:       item.Title,
+			Link:        link,
+			Description: item.Description,
+			PubDate:     item.Date,
+		}
+
+		if item.Creator != "" {
+			feed.Items[ind].Authors = []string{item.Creator}
+		}
+	}
+
+	return &feed, nil
+}
@@ -1,81 +1,158 @@
 package rss
 
 import (
-	"context"
+	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"html"
 	"io"
-	"net/http"
+	"mime"
 )
 
+// Feed is the format-independent representation of a fetched feed.
+// LastBuildDate is the time the content of the feed last changed and
+// is used when an item has no publication date of its own.
 type Feed struct {
-	Channel Channel `xml:"channel"`
-}
-
-type Channel struct {
-	Title       string `xml:"title"`
-	Link        string `xml:"link"`
-	Description string `xml:"description"`
-	Items       []Item `xml:"item"`
+	Title         string
+	Link          string
+	Description   string
+	LastBuildDate string
+	Hints         FetchHints
+	Items         []Item
 }
 
+// Item is the format-independent representation of an entry in a feed.
+// GUID is the identifier that the feed assigns to the item and is empty
+// if the feed does not provide one.
 type Item struct {
-	Title       string `xml:"title"`
-	Link        string `xml:"link"`
-	Description string `xml:"description"`
-	PubDate     string `xml:"pubDate"`
+	GUID        string
+	Title       string
+	Link        string
+	Description string
+	PubDate     string
+	Authors     []string
 }
 
-func FetchFeed(ctx context.Context, url string) (*Feed, error) {
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("received an error creating the HTTP request: %w", err)
-	}
+var byteOrderMark = []byte("\xef\xbb\xbf")
 
-	request.Header.Set("User-Agent", "Gator/0.0.0")
+type format int
 
-	client := http.Client{}
+const (
+	formatUnknown format = iota
+	formatRSS
+	formatAtom
+	formatRDF
+	formatJSONFeed
+)
 
-	response, err := client.Do(request)
-	if err != nil {
-		return nil, fmt.Errorf("error getting the response from the server: %w", err)
+const (
+	atomNamespace = "http://www.w3.org/2005/Atom"
+)
+
+// Parse detects the format of the feed document and decodes it
+// into a Feed. The content type is optional and is used to identify
+// JSON feeds before falling back to inspecting the document itself.
+func Parse(data []byte, contentType string) (*Feed, error) {
+	var err error
+
+	docFormat := formatJSONFeed
+
+	if !isJSON(data, contentType) {
+		data, err = toUTF8(data, contentType)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert the feed to UTF-8: %w", err)
+		}
+
+		docFormat, err = detectXMLFormat(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to detect the format of the feed: %w", err)
+		}
 	}
-	defer response.Body.Close()
-
-	if response.StatusCode >= 400 {
-		return nil, fmt.Errorf(
-			"received a bad status from %s: (%d) %s",
-			url,
-			response.StatusCode,
-			response.Status,
-		)
+
+	var feed *Feed
+
+	switch docFormat {
+	case formatRSS:
+		feed, err = parseRSS(data)
+	case formatAtom:
+		feed, err = parseAtom(data)
+	case formatRDF:
+		feed, err = parseRDF(data)
+	case formatJSONFeed:
+		feed, err = parseJSONFeed(data)
+	default:
+		err = errors.New("the document is not a supported feed format")
 	}
 
-	data, err := io.ReadAll(response.Body)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"unable to read the response from the server: %w",
-			err,
-		)
+		return nil, err
 	}
 
-	var feed Feed
+	// JSON does not escape entities so JSON feeds are returned as they
+	// are decoded.
+	if docFormat == formatJSONFeed {
+		return feed, nil
+	}
+
+	// The titles are plain text but may still contain entities that were
+	// escaped twice. The descriptions of the items are HTML and are left
+	// as they are so that escaped markup is not turned into real markup.
+	feed.Title = html.UnescapeString(feed.Title)
+	feed.Description = html.UnescapeString(feed.Description)
 
-	if err := xml.Unmarshal(data, &feed); err != nil {
-		return nil, fmt.Errorf(
-			"unable to decode the XML data: %w",
-			err,
-		)
+	for ind := range feed.Items {
+		feed.Items[ind].Title = html.UnescapeString(feed.Items[ind].Title)
 	}
 
-	feed.Channel.Title = html.UnescapeString(feed.Channel.Title)
-	feed.Channel.Description = html.UnescapeString(feed.Channel.Description)
+	return feed, nil
+}
+
+// isJSON reports whether the feed is a JSON document based on the
+// content type of the response or, failing that, the leading brace of
+// the document.
+func isJSON(data []byte, contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
 
-	for _, item := range feed.Channel.Items {
-		item.Title = html.UnescapeString(item.Title)
-		item.Description = html.UnescapeString(item.Description)
+	switch mediaType {
+	case "application/feed+json", "application/json":
+		return true
 	}
 
-	return &feed, nil
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, byteOrderMark))
+
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+// detectXMLFormat inspects the root element of the XML document to
+// determine the format of the feed.
+func detectXMLFormat(data []byte) (format, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return formatUnknown, errors.New("no root element found")
+			}
+
+			return formatUnknown, fmt.Errorf("unable to decode the XML data: %w", err)
+		}
+
+		root, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case root.Name.Local == "rss":
+			return formatRSS, nil
+		case root.Name.Local == "feed" && root.Name.Space == atomNamespace:
+			return formatAtom, nil
+		case root.Name.Local == "RDF" && root.Name.Space == rdfNamespace:
+			return formatRDF, nil
+		default:
+			return formatUnknown, nil
+		}
+	}
 }
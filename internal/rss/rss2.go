@@ -0,0 +1,107 @@
+package rss
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type rssFeed struct {
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	PubDate       string    `xml:"pubDate"`
+	TTL           string    `xml:"ttl"`
+	SkipHours     []string  `xml:"skipHours>hour"`
+	SkipDays      []string  `xml:"skipDays>day"`
+	Items         []rssItem `xml:"item"`
+	syndicationModule
+}
+
+type rssItem struct {
+	GUID        rssGUID `xml:"guid"`
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	PubDate     string  `xml:"pubDate"`
+	Author      string  `xml:"author"`
+	DCDate      string  `xml:"http://purl.org/dc/elements/1.1/ date"`
+	DCCreator   string  `xml:"http://purl.org/dc/elements/1.1/ creator"`
+}
+
+// rssGUID is the guid element of an item. The GUID is a permanent link
+// to the item unless the isPermaLink attribute is set to false.
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink string `xml:"isPermaLink,attr"`
+}
+
+func parseRSS(data []byte) (*Feed, error) {
+	var doc rssFeed
+
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf(
+			"unable to decode the RSS document: %w",
+			err,
+		)
+	}
+
+	lastBuildDate := doc.Channel.LastBuildDate
+	if lastBuildDate == "" {
+		lastBuildDate = doc.Channel.PubDate
+	}
+
+	feed := Feed{
+		Title:         doc.Channel.Title,
+		Link:          doc.Channel.Link,
+		Description:   doc.Channel.Description,
+		LastBuildDate: lastBuildDate,
+		Hints: FetchHints{
+			TTL:          parseTTL(doc.Channel.TTL),
+			UpdatePeriod: doc.Channel.updatePeriod(),
+			SkipHours:    parseSkipHours(doc.Channel.SkipHours),
+			SkipDays:     parseSkipDays(doc.Channel.SkipDays),
+		},
+		Items: make([]Item, len(doc.Channel.Items)),
+	}
+
+	for ind, item := range doc.Channel.Items {
+		// Some RSS 2.0 feeds use the Dublin Core module in place
+		// of the pubDate and author elements.
+		pubDate := item.PubDate
+		if pubDate == "" {
+			pubDate = item.DCDate
+		}
+
+		author := item.Author
+		if author == "" {
+			author = item.DCCreator
+		}
+
+		guid := strings.TrimSpace(item.GUID.Value)
+
+		link := strings.TrimSpace(item.Link)
+		if link == "" && guid != "" && item.GUID.IsPermaLink != "false" {
+			link = guid
+		}
+
+		feed.Items[ind] = Item{
+			GUID:        guid,
+			Title:       item.Title,
+			Link:        link,
+			Description: item.Description,
+			PubDate:     pubDate,
+		}
+
+		if author != "" {
+			feed.Items[ind].Authors = []string{author}
+		}
+	}
+
+	return &feed, nil
+}
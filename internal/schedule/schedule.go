@@ -0,0 +1,128 @@
+// Package schedule calculates when a feed should next be fetched.
+//
+// The interval between fetches adapts to how often the feed publishes
+// new items while respecting the hints given by the publisher.
+package schedule
+
+import (
+	"slices"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/rss"
+)
+
+const (
+	// MaxInterval is the longest time between fetches of a feed.
+	MaxInterval = 24 * time.Hour
+
+	// sampleSize is the number of the most recent publication dates
+	// used to estimate how often the feed publishes.
+	sampleSize = 10
+)
+
+// Interval returns the interval until the next fetch of the feed.
+//
+// The target interval is half of the median time between the most
+// recent publication dates so that new items are usually picked up
+// within half a publishing period. The target is averaged with the
+// current interval to smooth out bursts of activity. The result is
+// never shorter than the TTL or update period hinted by the publisher
+// and is clamped between the minimum interval and MaxInterval.
+func Interval(
+	current time.Duration,
+	minimum time.Duration,
+	hints rss.FetchHints,
+	pubDates []time.Time,
+) time.Duration {
+	interval := current
+	if interval <= 0 {
+		interval = minimum
+	}
+
+	if gap, ok := medianGap(pubDates); ok {
+		interval = (interval + gap/2) / 2
+	}
+
+	interval = max(interval, hints.TTL, hints.UpdatePeriod)
+
+	return min(max(interval, minimum), MaxInterval)
+}
+
+// Backoff returns the time to wait before retrying a feed that has
+// failed the given number of consecutive times. The wait doubles with
+// each failure starting from the base interval up to MaxInterval.
+func Backoff(base time.Duration, failures int) time.Duration {
+	if failures < 1 {
+		return base
+	}
+
+	backoff := base
+
+	for range failures - 1 {
+		backoff *= 2
+
+		if backoff >= MaxInterval {
+			return MaxInterval
+		}
+	}
+
+	return min(backoff, MaxInterval)
+}
+
+// NextFetch returns the time of the next fetch after the interval has
+// passed, moved forward past any hours or days that the publisher asked
+// to skip.
+func NextFetch(from time.Time, interval time.Duration, hints rss.FetchHints) time.Time {
+	next := from.Add(interval)
+
+	if len(hints.SkipHours) == 0 && len(hints.SkipDays) == 0 {
+		return next
+	}
+
+	// The skip hints are expressed in GMT. The search is bounded
+	// to a week in case the publisher asks to skip every hour.
+	for range 7 * 24 {
+		utc := next.UTC()
+
+		if !slices.Contains(hints.SkipHours, utc.Hour()) && !slices.Contains(hints.SkipDays, utc.Weekday()) {
+			return next
+		}
+
+		next = utc.Truncate(time.Hour).Add(time.Hour)
+	}
+
+	return from.Add(interval)
+}
+
+// medianGap returns the median time between the most recent
+// publication dates.
+func medianGap(pubDates []time.Time) (time.Duration, bool) {
+	if len(pubDates) < 2 {
+		return 0, false
+	}
+
+	sorted := slices.Clone(pubDates)
+	slices.SortFunc(sorted, func(a, b time.Time) int {
+		return b.Compare(a)
+	})
+
+	if len(sorted) > sampleSize {
+		sorted = sorted[:sampleSize]
+	}
+
+	gaps := make([]time.Duration, 0, len(sorted)-1)
+
+	for ind := 1; ind < len(sorted); ind++ {
+		if gap := sorted[ind-1].Sub(sorted[ind]); gap > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+
+	if len(gaps) == 0 {
+		return 0, false
+	}
+
+	slices.Sort(gaps)
+
+	return gaps[len(gaps)/2], true
+}
@@ -3,9 +3,11 @@ package state
 import (
 	"codeflow.dananglin.me.uk/apollo/gator/internal/config"
 	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/httpclient"
 )
 
 type State struct {
-	DB     *database.Queries
-	Config *config.Config
+	DB         *database.Queries
+	Config     *config.Config
+	HTTPClient *httpclient.Client
 }
@@ -0,0 +1,607 @@
+// Package tui is the full screen terminal interface for reading the
+// posts from the feeds that a user follows.
+//
+// The screen is split into a sidebar listing the categories and feeds,
+// the list of posts from the selected category or feed and a preview of
+// the selected post. The interface reads from the same queries that the
+// command line uses and only depends on the standard library, driving
+// the terminal with ANSI escape sequences.
+package tui
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/htmltext"
+	"github.com/google/uuid"
+)
+
+// pageSize is the number of posts loaded at a time. More posts are
+// loaded when the selection reaches the end of the list.
+const pageSize = 100
+
+type pane int
+
+const (
+	sidebarPane pane = iota
+	postsPane
+	previewPane
+)
+
+// source is an entry of the sidebar. The posts from every followed feed
+// are shown when both the category and the feed ID are empty.
+type source struct {
+	label    string
+	category string
+	feedID   uuid.NullUUID
+}
+
+// list is the selection and scroll position of a list.
+type list struct {
+	selected int
+	offset   int
+}
+
+type app struct {
+	db   *database.Queries
+	user database.User
+	out  *bufio.Writer
+
+	width  int
+	height int
+	focus  pane
+
+	sources       []source
+	sourcesList   list
+	posts         []database.GetPostsForUserRow
+	postsList     list
+	morePosts     bool
+	includeRead   bool
+	previewPostID uuid.UUID
+	previewText   string
+	previewLines  []string
+	previewOffset int
+	status        string
+}
+
+// Run runs the terminal interface for the user until the user quits.
+func Run(db *database.Queries, user database.User) error {
+	term, err := openTerminal()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = term.restore()
+	}()
+
+	a := app{
+		db:   db,
+		user: user,
+		out:  bufio.NewWriter(os.Stdout),
+	}
+
+	a.width, a.height, err = term.size()
+	if err != nil {
+		return err
+	}
+
+	a.out.WriteString(enterAlternateScreen + hideCursor)
+
+	defer func() {
+		a.out.WriteString(showCursor + exitAlternateScreen)
+		_ = a.out.Flush()
+	}()
+
+	if err := a.loadSources(); err != nil {
+		return err
+	}
+
+	if err := a.loadPosts(); err != nil {
+		return err
+	}
+
+	keys := make(chan string)
+	go readKeys(os.Stdin, keys)
+
+	resizes := make(chan os.Signal, 1)
+	notifyResize(resizes)
+
+	for {
+		a.draw()
+
+		if err := a.out.Flush(); err != nil {
+			return fmt.Errorf("unable to draw the screen: %w", err)
+		}
+
+		select {
+		case key, ok := <-keys:
+			if !ok || key == "q" || key == keyInterrupt {
+				return nil
+			}
+
+			a.status = ""
+			a.handleKey(key)
+		case <-resizes:
+			if width, height, err := term.size(); err == nil {
+				a.width, a.height = width, height
+				a.wrapPreview()
+			}
+		}
+	}
+}
+
+// loadSources loads the sidebar from the feeds that the user follows.
+// Each category is listed before the feeds in it.
+func (a *app) loadSources() error {
+	feeds, err := a.db.GetFollowedFeedsForUser(context.Background(), a.user.ID)
+	if err != nil {
+		return fmt.Errorf("unable to get the followed feeds from the database: %w", err)
+	}
+
+	a.sources = []source{{label: "All posts"}}
+
+	category := ""
+
+	for _, feed := range feeds {
+		if feed.Category != category {
+			category = feed.Category
+			a.sources = append(a.sources, source{label: category, category: category})
+		}
+
+		label := feed.Name
+		if category != "" {
+			label = "  " + label
+		}
+
+		a.sources = append(a.sources, source{
+			label:  label,
+			feedID: uuid.NullUUID{UUID: feed.ID, Valid: true},
+		})
+	}
+
+	a.sourcesList.selected = min(a.sourcesList.selected, len(a.sources)-1)
+
+	return nil
+}
+
+// loadPosts loads the first page of posts from the selected source.
+func (a *app) loadPosts() error {
+	posts, err := a.queryPosts(nil)
+	if err != nil {
+		return err
+	}
+
+	a.posts = posts
+	a.morePosts = len(posts) == pageSize
+	a.postsList = list{}
+
+	return a.loadPreview()
+}
+
+// loadMorePosts appends the next page of posts to the list.
+func (a *app) loadMorePosts() error {
+	if !a.morePosts || len(a.posts) == 0 {
+		return nil
+	}
+
+	posts, err := a.queryPosts(&a.posts[len(a.posts)-1])
+	if err != nil {
+		return err
+	}
+
+	a.posts = append(a.posts, posts...)
+	a.morePosts = len(posts) == pageSize
+
+	return nil
+}
+
+// queryPosts returns a page of posts from the selected source, starting
+// after the given post if it is set.
+func (a *app) queryPosts(after *database.GetPostsForUserRow) ([]database.GetPostsForUserRow, error) {
+	selected := a.sources[a.sourcesList.selected]
+
+	args := database.GetPostsForUserParams{
+		UserID:      a.user.ID,
+		IncludeRead: a.includeRead,
+		FeedID:      selected.feedID,
+		Category:    selected.category,
+		MaxPosts:    pageSize,
+	}
+
+	if after != nil {
+		args.AfterPublishedAt = sql.NullTime{Time: after.PublishedAt, Valid: true}
+		args.AfterID = uuid.NullUUID{UUID: after.ID, Valid: true}
+	}
+
+	posts, err := a.db.GetPostsForUser(context.Background(), args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the posts from the database: %w", err)
+	}
+
+	return posts, nil
+}
+
+// loadPreview loads the description of the selected post for the
+// preview pane.
+func (a *app) loadPreview() error {
+	post, ok := a.selectedPost()
+	if !ok {
+		a.previewPostID = uuid.Nil
+		a.previewText = ""
+		a.wrapPreview()
+
+		return nil
+	}
+
+	if post.ID == a.previewPostID {
+		return nil
+	}
+
+	details, err := a.db.GetPostByID(context.Background(), post.ID)
+	if err != nil {
+		return fmt.Errorf("unable to get the post from the database: %w", err)
+	}
+
+	body := htmltext.ToText(details.Description)
+	if body == "" {
+		body = "This post has no description."
+	}
+
+	a.previewPostID = post.ID
+	a.previewText = strings.Join([]string{
+		details.Title,
+		post.FeedName + " · " + details.PublishedAt.Local().Format("Mon, 02 Jan 2006 15:04"),
+		details.Url,
+		"",
+		body,
+	}, "\n")
+	a.previewOffset = 0
+	a.wrapPreview()
+
+	return nil
+}
+
+// wrapPreview wraps the text of the preview to the width of the
+// preview pane.
+func (a *app) wrapPreview() {
+	a.previewLines = htmltext.Wrap(a.previewText, a.layout().previewWidth-2)
+	a.previewOffset = min(a.previewOffset, max(len(a.previewLines)-1, 0))
+}
+
+func (a *app) selectedPost() (*database.GetPostsForUserRow, bool) {
+	if len(a.posts) == 0 {
+		return nil, false
+	}
+
+	return &a.posts[a.postsList.selected], true
+}
+
+// handleKey runs the action bound to the key. Errors are reported in
+// the status line.
+func (a *app) handleKey(key string) {
+	var err error
+
+	switch key {
+	case keyTab:
+		a.focus = (a.focus + 1) % 3
+	case keyBackTab:
+		a.focus = (a.focus + 2) % 3
+	case "h", keyLeft:
+		a.focus = max(a.focus-1, sidebarPane)
+	case "l", keyRight:
+		a.focus = min(a.focus+1, previewPane)
+	case "a":
+		a.includeRead = !a.includeRead
+		err = a.loadPosts()
+	case "r":
+		err = a.reload()
+	case "m":
+		err = a.toggleRead()
+	case "s":
+		err = a.toggleStar()
+	case "o":
+		err = a.openPost()
+	case "n":
+		err = a.stepPost(1)
+	case "p":
+		err = a.stepPost(-1)
+	default:
+		err = a.handlePaneKey(key)
+	}
+
+	if err != nil {
+		a.status = "Error: " + err.Error()
+	}
+}
+
+// handlePaneKey handles the keys that act on the focused pane.
+func (a *app) handlePaneKey(key string) error {
+	layout := a.layout()
+
+	switch a.focus {
+	case sidebarPane:
+		if key == keyEnter {
+			a.focus = postsPane
+
+			return nil
+		}
+
+		previous := a.sourcesList.selected
+		if !a.sourcesList.move(key, len(a.sources), layout.bodyHeight) {
+			return nil
+		}
+
+		if a.sourcesList.selected != previous {
+			return a.loadPosts()
+		}
+	case postsPane:
+		if key == keyEnter {
+			a.focus = previewPane
+
+			return a.markRead()
+		}
+
+		if !a.postsList.move(key, len(a.posts), layout.postsHeight) {
+			return nil
+		}
+
+		if a.postsList.selected == len(a.posts)-1 {
+			if err := a.loadMorePosts(); err != nil {
+				return err
+			}
+		}
+
+		return a.loadPreview()
+	case previewPane:
+		a.scrollPreview(key, layout.previewHeight)
+	}
+
+	return nil
+}
+
+// move moves the selection of a list with the given number of items for
+// the navigation key and reports whether the key was a navigation key.
+func (l *list) move(key string, items, pageHeight int) bool {
+	if items == 0 {
+		return false
+	}
+
+	switch key {
+	case "j", keyDown:
+		l.selected++
+	case "k", keyUp:
+		l.selected--
+	case keyPageDown, " ":
+		l.selected += max(pageHeight-1, 1)
+	case keyPageUp:
+		l.selected -= max(pageHeight-1, 1)
+	case "g", keyHome:
+		l.selected = 0
+	case "G", keyEnd:
+		l.selected = items - 1
+	default:
+		return false
+	}
+
+	l.selected = max(min(l.selected, items-1), 0)
+
+	return true
+}
+
+// scroll adjusts the offset of the list so that the selection is
+// visible in a pane of the given height.
+func (l *list) scroll(height int) {
+	if l.selected < l.offset {
+		l.offset = l.selected
+	}
+
+	if l.selected >= l.offset+height {
+		l.offset = l.selected - height + 1
+	}
+}
+
+func (a *app) scrollPreview(key string, height int) {
+	switch key {
+	case "j", keyDown, keyEnter:
+		a.previewOffset++
+	case "k", keyUp:
+		a.previewOffset--
+	case keyPageDown, " ":
+		a.previewOffset += max(height-1, 1)
+	case keyPageUp:
+		a.previewOffset -= max(height-1, 1)
+	case "g", keyHome:
+		a.previewOffset = 0
+	case "G", keyEnd:
+		a.previewOffset = len(a.previewLines) - height
+	}
+
+	a.previewOffset = max(min(a.previewOffset, len(a.previewLines)-height), 0)
+}
+
+// stepPost selects the next or previous post, shows it in the preview
+// and marks it as read.
+func (a *app) stepPost(step int) error {
+	if len(a.posts) == 0 {
+		return nil
+	}
+
+	selected := a.postsList.selected + step
+	if selected < 0 || selected >= len(a.posts) {
+		return nil
+	}
+
+	a.postsList.selected = selected
+
+	if selected == len(a.posts)-1 {
+		if err := a.loadMorePosts(); err != nil {
+			return err
+		}
+	}
+
+	if err := a.loadPreview(); err != nil {
+		return err
+	}
+
+	return a.markRead()
+}
+
+// reload reloads the sidebar and the posts, keeping the selected post
+// if it is still listed.
+func (a *app) reload() error {
+	var selectedID uuid.UUID
+
+	if post, ok := a.selectedPost(); ok {
+		selectedID = post.ID
+	}
+
+	if err := a.loadSources(); err != nil {
+		return err
+	}
+
+	if err := a.loadPosts(); err != nil {
+		return err
+	}
+
+	for ind := range a.posts {
+		if a.posts[ind].ID == selectedID {
+			a.postsList.selected = ind
+
+			break
+		}
+	}
+
+	a.status = "Reloaded."
+
+	return a.loadPreview()
+}
+
+// markRead marks the selected post as read.
+func (a *app) markRead() error {
+	post, ok := a.selectedPost()
+	if !ok || post.ReadAt.Valid {
+		return nil
+	}
+
+	timestamp := time.Now()
+
+	args := database.MarkPostReadParams{
+		UserID: a.user.ID,
+		PostID: post.ID,
+		ReadAt: timestamp,
+	}
+
+	if _, err := a.db.MarkPostRead(context.Background(), args); err != nil {
+		return fmt.Errorf("unable to mark the post as read: %w", err)
+	}
+
+	post.ReadAt = sql.NullTime{Time: timestamp, Valid: true}
+
+	return nil
+}
+
+// toggleRead marks the selected post as read or unread.
+func (a *app) toggleRead() error {
+	post, ok := a.selectedPost()
+	if !ok {
+		return nil
+	}
+
+	if !post.ReadAt.Valid {
+		if err := a.markRead(); err != nil {
+			return err
+		}
+
+		a.status = "Marked as read."
+
+		return nil
+	}
+
+	args := database.MarkPostUnreadParams{
+		UserID: a.user.ID,
+		PostID: post.ID,
+	}
+
+	if _, err := a.db.MarkPostUnread(context.Background(), args); err != nil {
+		return fmt.Errorf("unable to mark the post as unread: %w", err)
+	}
+
+	post.ReadAt = sql.NullTime{}
+	a.status = "Marked as unread."
+
+	return nil
+}
+
+// toggleStar stars or unstars the selected post. Starring a post from
+// the terminal interface does not set a note or tags.
+func (a *app) toggleStar() error {
+	post, ok := a.selectedPost()
+	if !ok {
+		return nil
+	}
+
+	if post.StarredAt.Valid {
+		args := database.UnstarPostParams{
+			UserID: a.user.ID,
+			PostID: post.ID,
+		}
+
+		if _, err := a.db.UnstarPost(context.Background(), args); err != nil {
+			return fmt.Errorf("unable to unstar the post: %w", err)
+		}
+
+		post.StarredAt = sql.NullTime{}
+		a.status = "Removed the star."
+
+		return nil
+	}
+
+	timestamp := time.Now()
+
+	args := database.StarPostParams{
+		UserID:    a.user.ID,
+		PostID:    post.ID,
+		Note:      "",
+		Tags:      make([]string, 0),
+		CreatedAt: timestamp,
+		UpdatedAt: timestamp,
+	}
+
+	if _, err := a.db.StarPost(context.Background(), args); err != nil {
+		return fmt.Errorf("unable to star the post: %w", err)
+	}
+
+	post.StarredAt = sql.NullTime{Time: timestamp, Valid: true}
+	a.status = "Starred."
+
+	return nil
+}
+
+// openPost opens the selected post in the web browser and marks it as
+// read.
+func (a *app) openPost() error {
+	post, ok := a.selectedPost()
+	if !ok {
+		return nil
+	}
+
+	if post.Url == "" {
+		return errors.New("the post does not have a link")
+	}
+
+	if err := openBrowser(post.Url); err != nil {
+		return err
+	}
+
+	a.status = "Opened " + post.Url
+
+	return a.markRead()
+}
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// The names of the special keys. Printable keys are named by the
+// character that they type.
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyLeft      = "left"
+	keyRight     = "right"
+	keyHome      = "home"
+	keyEnd       = "end"
+	keyPageUp    = "pgup"
+	keyPageDown  = "pgdown"
+	keyEnter     = "enter"
+	keyTab       = "tab"
+	keyBackTab   = "backtab"
+	keyEscape    = "esc"
+	keyInterrupt = "ctrl+c"
+)
+
+// readKeys reads the key presses from the reader and sends them to the
+// channel until the reader fails.
+func readKeys(reader io.Reader, keys chan<- string) {
+	buf := make([]byte, 64)
+
+	for {
+		n, err := reader.Read(buf)
+		if err != nil {
+			close(keys)
+
+			return
+		}
+
+		for _, key := range parseKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// parseKeys splits the bytes read from the terminal into key presses.
+// The escape sequences of the cursor and paging keys are recognised and
+// anything else that is not printable is ignored.
+func parseKeys(data []byte) []string {
+	var keys []string
+
+	for len(data) > 0 {
+		switch data[0] {
+		case 0x1b:
+			key, size := parseEscape(data)
+			if key != "" {
+				keys = append(keys, key)
+			}
+
+			data = data[size:]
+
+			continue
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case '\t':
+			keys = append(keys, keyTab)
+		case 0x03:
+			keys = append(keys, keyInterrupt)
+		default:
+			char, size := utf8.DecodeRune(data)
+			if char >= ' ' && char != 0x7f && char != utf8.RuneError {
+				keys = append(keys, string(char))
+			}
+
+			data = data[size:]
+
+			continue
+		}
+
+		data = data[1:]
+	}
+
+	return keys
+}
+
+// parseEscape parses the escape sequence at the start of the data and
+// returns the key and the length of the sequence. Unknown sequences
+// return an empty key.
+func parseEscape(data []byte) (string, int) {
+	if len(data) < 2 || (data[1] != '[' && data[1] != 'O') {
+		return keyEscape, 1
+	}
+
+	end := 2
+	for end < len(data) && (data[end] < 0x40 || data[end] > 0x7e) {
+		end++
+	}
+
+	if end == len(data) {
+		return "", len(data)
+	}
+
+	params := string(data[2:end])
+
+	switch data[end] {
+	case 'A':
+		return keyUp, end + 1
+	case 'B':
+		return keyDown, end + 1
+	case 'C':
+		return keyRight, end + 1
+	case 'D':
+		return keyLeft, end + 1
+	case 'H':
+		return keyHome, end + 1
+	case 'F':
+		return keyEnd, end + 1
+	case 'Z':
+		return keyBackTab, end + 1
+	case '~':
+		switch params {
+		case "1", "7":
+			return keyHome, end + 1
+		case "4", "8":
+			return keyEnd, end + 1
+		case "5":
+			return keyPageUp, end + 1
+		case "6":
+			return keyPageDown, end + 1
+		}
+	}
+
+	return "", end + 1
+}
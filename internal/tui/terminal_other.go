@@ -0,0 +1,26 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package tui
+
+import (
+	"errors"
+	"os"
+)
+
+var errUnsupported = errors.New("the terminal interface is not supported on this operating system")
+
+type terminal struct{}
+
+func openTerminal() (*terminal, error) {
+	return nil, errUnsupported
+}
+
+func (t *terminal) restore() error {
+	return errUnsupported
+}
+
+func (t *terminal) size() (int, int, error) {
+	return 0, 0, errUnsupported
+}
+
+func notifyResize(_ chan<- os.Signal) {}
@@ -0,0 +1,86 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// terminal is the controlling terminal in raw mode. The original
+// settings are kept so that they can be restored on exit.
+type terminal struct {
+	fd       int
+	original syscall.Termios
+}
+
+type windowSize struct {
+	rows    uint16
+	columns uint16
+	xPixels uint16
+	yPixels uint16
+}
+
+// openTerminal puts the terminal on standard input into raw mode so
+// that the key presses are read as they are typed, without echo and
+// without the terminal handling the control characters.
+func openTerminal() (*terminal, error) {
+	fd := int(os.Stdin.Fd())
+
+	var original syscall.Termios
+
+	if err := ioctl(fd, ioctlGetTermios, unsafe.Pointer(&original)); err != nil {
+		return nil, fmt.Errorf("the standard input is not a terminal: %w", err)
+	}
+
+	raw := original
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	if err := ioctl(fd, ioctlSetTermios, unsafe.Pointer(&raw)); err != nil {
+		return nil, fmt.Errorf("unable to put the terminal into raw mode: %w", err)
+	}
+
+	return &terminal{fd: fd, original: original}, nil
+}
+
+// restore restores the original settings of the terminal.
+func (t *terminal) restore() error {
+	if err := ioctl(t.fd, ioctlSetTermios, unsafe.Pointer(&t.original)); err != nil {
+		return fmt.Errorf("unable to restore the terminal settings: %w", err)
+	}
+
+	return nil
+}
+
+// size returns the number of columns and rows of the terminal.
+func (t *terminal) size() (int, int, error) {
+	var size windowSize
+
+	if err := ioctl(int(os.Stdout.Fd()), syscall.TIOCGWINSZ, unsafe.Pointer(&size)); err != nil {
+		return 0, 0, fmt.Errorf("unable to get the size of the terminal: %w", err)
+	}
+
+	return int(size.columns), int(size.rows), nil
+}
+
+// notifyResize relays the signals sent when the terminal is resized.
+func notifyResize(signals chan<- os.Signal) {
+	signal.Notify(signals, syscall.SIGWINCH)
+}
+
+func ioctl(fd int, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(arg)); errno != 0 {
+		return errno
+	}
+
+	return nil
+}
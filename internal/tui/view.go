@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	enterAlternateScreen = "\x1b[?1049h"
+	exitAlternateScreen  = "\x1b[?1049l"
+	hideCursor           = "\x1b[?25l"
+	showCursor           = "\x1b[?25h"
+
+	styleReset   = "\x1b[0m"
+	styleBold    = "\x1b[1m"
+	styleDim     = "\x1b[2m"
+	styleUnder   = "\x1b[4m"
+	styleReverse = "\x1b[7m"
+)
+
+const helpLine = "q quit  tab switch pane  j/k move  enter open  n/p next/previous  " +
+	"m read/unread  s star  o open in browser  a all/unread  r reload"
+
+// layout is the size of the panes for the size of the terminal. The
+// sidebar is on the left and the post list is above the preview on the
+// right. The first row is the title bar and the last row is the status
+// line.
+type layout struct {
+	sidebarWidth  int
+	previewWidth  int
+	bodyHeight    int
+	postsHeight   int
+	previewHeight int
+}
+
+func (a *app) layout() layout {
+	bodyHeight := max(a.height-2, 3)
+	sidebarWidth := min(max(a.width/4, 16), 32, a.width/2)
+	postsHeight := max(bodyHeight*2/5, 1)
+
+	return layout{
+		sidebarWidth:  sidebarWidth,
+		previewWidth:  max(a.width-sidebarWidth-1, 1),
+		bodyHeight:    bodyHeight,
+		postsHeight:   postsHeight,
+		previewHeight: max(bodyHeight-postsHeight-1, 1),
+	}
+}
+
+// draw writes the whole screen to the output buffer.
+func (a *app) draw() {
+	l := a.layout()
+
+	a.sourcesList.scroll(l.bodyHeight)
+	a.postsList.scroll(l.postsHeight)
+
+	filter := "unread posts"
+	if a.includeRead {
+		filter = "all posts"
+	}
+
+	title := fmt.Sprintf(" gator · %s · %s · %s", a.user.Name, a.sources[a.sourcesList.selected].label, filter)
+	a.writeRow(1, styleReverse+cell(title, a.width)+styleReset)
+
+	for row := range l.bodyHeight {
+		a.writeRow(row+2, a.sidebarCell(row, l)+styleDim+"│"+styleReset+a.rightCell(row, l))
+	}
+
+	status := a.status
+	if status == "" {
+		status = helpLine
+	}
+
+	a.writeRow(a.height, styleDim+cell(" "+status, a.width)+styleReset)
+}
+
+func (a *app) writeRow(row int, content string) {
+	fmt.Fprintf(a.out, "\x1b[%d;1H%s", row, content)
+}
+
+func (a *app) sidebarCell(row int, l layout) string {
+	ind := a.sourcesList.offset + row
+	if ind >= len(a.sources) {
+		return cell("", l.sidebarWidth)
+	}
+
+	entry := a.sources[ind]
+	text := cell(" "+entry.label, l.sidebarWidth)
+
+	return a.styleItem(text, ind == a.sourcesList.selected, sidebarPane, !entry.feedID.Valid)
+}
+
+// rightCell returns the row of the post list, the separator or the
+// preview for the row of the body.
+func (a *app) rightCell(row int, l layout) string {
+	switch {
+	case row < l.postsHeight:
+		return a.postCell(a.postsList.offset+row, l.previewWidth)
+	case row == l.postsHeight:
+		return styleDim + strings.Repeat("─", l.previewWidth) + styleReset
+	}
+
+	ind := a.previewOffset + row - l.postsHeight - 1
+	if ind >= len(a.previewLines) {
+		return cell("", l.previewWidth)
+	}
+
+	return cell(" "+a.previewLines[ind], l.previewWidth)
+}
+
+func (a *app) postCell(ind, width int) string {
+	if ind >= len(a.posts) {
+		if ind == 0 {
+			return cell(" There are no posts to show.", width)
+		}
+
+		return cell("", width)
+	}
+
+	post := a.posts[ind]
+
+	unread := "●"
+	if post.ReadAt.Valid {
+		unread = " "
+	}
+
+	starred := " "
+	if post.StarredAt.Valid {
+		starred = "★"
+	}
+
+	text := fmt.Sprintf(" %s%s %s  %s", unread, starred, post.PublishedAt.Local().Format("Jan 02"), post.Title)
+
+	// The name of the feed is only useful when the posts are from more
+	// than one feed.
+	if !a.sources[a.sourcesList.selected].feedID.Valid {
+		text += "  (" + post.FeedName + ")"
+	}
+
+	return a.styleItem(cell(text, width), ind == a.postsList.selected, postsPane, !post.ReadAt.Valid)
+}
+
+// styleItem styles an item of a list. The selected item is highlighted
+// when its pane has the focus and is underlined otherwise.
+func (a *app) styleItem(text string, selected bool, itemPane pane, bold bool) string {
+	switch {
+	case selected && a.focus == itemPane:
+		return styleReverse + text + styleReset
+	case selected && bold:
+		return styleBold + styleUnder + text + styleReset
+	case selected:
+		return styleUnder + text + styleReset
+	case bold:
+		return styleBold + text + styleReset
+	default:
+		return text
+	}
+}
+
+// cell truncates or pads the text to the width. Control characters are
+// removed so that the text cannot move the cursor.
+func cell(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	text = strings.Map(func(char rune) rune {
+		if char < ' ' || (char >= 0x7f && char < 0xa0) {
+			return -1
+		}
+
+		return char
+	}, text)
+
+	length := utf8.RuneCountInString(text)
+
+	if length > width {
+		runes := []rune(text)
+
+		return string(runes[:max(width-1, 0)]) + "…"
+	}
+
+	return text + strings.Repeat(" ", width-length)
+}
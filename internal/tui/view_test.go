@@ -0,0 +1,73 @@
+package tui
+
+import "testing"
+
+func TestCell(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{
+			name:  "padded",
+			text:  "abc",
+			width: 5,
+			want:  "abc  ",
+		},
+		{
+			name:  "exact width",
+			text:  "abcde",
+			width: 5,
+			want:  "abcde",
+		},
+		{
+			name:  "truncated",
+			text:  "abcdefgh",
+			width: 5,
+			want:  "abcd…",
+		},
+		{
+			name:  "truncated by runes",
+			text:  "éèêëē",
+			width: 3,
+			want:  "éè…",
+		},
+		{
+			name:  "escape sequences removed",
+			text:  "a\x1b[2Jb",
+			width: 6,
+			want:  "a[2Jb ",
+		},
+		{
+			name:  "control characters removed",
+			text:  "a\tb\r\nc\x7f\u009bd",
+			width: 4,
+			want:  "abcd",
+		},
+		{
+			name:  "zero width",
+			text:  "abc",
+			width: 0,
+			want:  "",
+		},
+		{
+			name:  "width of one",
+			text:  "abc",
+			width: 1,
+			want:  "…",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := cell(tc.text, tc.width); got != tc.want {
+				t.Errorf("cell(%q, %d) = %q, want %q", tc.text, tc.width, got, tc.want)
+			}
+		})
+	}
+}
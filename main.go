@@ -2,13 +2,13 @@ package main
 
 import (
 	"database/sql"
-	"errors"
 	"fmt"
 	"os"
 
 	"codeflow.dananglin.me.uk/apollo/gator/internal/config"
 	"codeflow.dananglin.me.uk/apollo/gator/internal/database"
 	"codeflow.dananglin.me.uk/apollo/gator/internal/executors"
+	"codeflow.dananglin.me.uk/apollo/gator/internal/httpclient"
 	"codeflow.dananglin.me.uk/apollo/gator/internal/state"
 	_ "github.com/lib/pq"
 )
@@ -28,6 +28,23 @@ func main() {
 }
 
 func run() error {
+	executorMap := executors.NewExecutorMap()
+
+	for _, command := range executors.Commands() {
+		executorMap.Register(command)
+	}
+
+	executor, err := parseArgs(os.Args[1:])
+	if err != nil {
+		return fmt.Errorf("unable to parse the command: %w", err)
+	}
+
+	// The help is shown without loading the configuration so that it
+	// is available before gator is set up.
+	if executorMap.IsHelp(executor) {
+		return executorMap.Run(nil, executor)
+	}
+
 	cfg, err := config.NewConfig()
 	if err != nil {
 		return fmt.Errorf("unable to load the configuration: %w", err)
@@ -38,30 +55,15 @@ func run() error {
 		return fmt.Errorf("unable to open a connection to the database: %w", err)
 	}
 
-	s := state.State{
-		DB:     database.New(db),
-		Config: &cfg,
-	}
-
-	executorMap := executors.ExecutorMap{
-		Map: make(map[string]executors.ExecutorFunc),
+	httpClient, err := httpclient.New(cfg.HTTPConfig)
+	if err != nil {
+		return fmt.Errorf("unable to create the HTTP client: %w", err)
 	}
 
-	executorMap.Register("login", executors.Login)
-	executorMap.Register("register", executors.Register)
-	executorMap.Register("reset", executors.Reset)
-	executorMap.Register("users", executors.Users)
-	executorMap.Register("aggregate", executors.Aggregate)
-	executorMap.Register("addfeed", executors.MiddlewareLoggedIn(executors.AddFeed))
-	executorMap.Register("feeds", executors.Feeds)
-	executorMap.Register("follow", executors.MiddlewareLoggedIn(executors.Follow))
-	executorMap.Register("unfollow", executors.MiddlewareLoggedIn(executors.Unfollow))
-	executorMap.Register("following", executors.MiddlewareLoggedIn(executors.Following))
-	executorMap.Register("browse", executors.MiddlewareLoggedIn(executors.Browse))
-
-	executor, err := parseArgs(os.Args[1:])
-	if err != nil {
-		return fmt.Errorf("unable to parse the command: %w", err)
+	s := state.State{
+		DB:         database.New(db),
+		Config:     &cfg,
+		HTTPClient: httpClient,
 	}
 
 	return executorMap.Run(&s, executor)
@@ -69,7 +71,18 @@ func run() error {
 
 func parseArgs(args []string) (executors.Executor, error) {
 	if len(args) == 0 {
-		return executors.Executor{}, errors.New("no arguments given")
+		return executors.Executor{
+			Name: "help",
+			Args: make([]string, 0),
+		}, nil
+	}
+
+	// "gator -h" and "gator --help" are the same as "gator help".
+	if args[0] == "-h" || args[0] == "--help" {
+		return executors.Executor{
+			Name: "help",
+			Args: args[1:],
+		}, nil
 	}
 
 	if len(args) == 1 {
@@ -5,14 +5,16 @@ WITH inserted_feed_follow AS (
     created_at,
     updated_at,
     user_id,
-    feed_id
+    feed_id,
+    category
   )
   VALUES (
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
   )
   RETURNING *
 )
@@ -21,12 +23,27 @@ FROM inserted_feed_follow
 INNER JOIN users ON users.id = inserted_feed_follow.user_id
 INNER JOIN feeds ON feeds.id = inserted_feed_follow.feed_id;
 
--- name: GetFeedFollowsForUser :many
-SELECT feeds.name as feeds_name
+-- name: GetFollowedFeedsForUser :many
+SELECT feeds.id, feeds.name, feeds.url, feeds.site_url, feed_follows.category
 FROM feed_follows
 INNER JOIN feeds ON feed_follows.feed_id = feeds.id
-WHERE feed_follows.user_id = $1;
+WHERE feed_follows.user_id = $1
+ORDER BY feed_follows.category, feeds.name;
 
 -- name: DeleteFeedFollow :exec
 DELETE FROM feed_follows
 WHERE user_id = $1 AND feed_id = $2;
+
+-- name: MoveFeedFollows :exec
+INSERT INTO feed_follows (
+  id,
+  created_at,
+  updated_at,
+  user_id,
+  feed_id,
+  category
+)
+SELECT gen_random_uuid(), created_at, sqlc.arg(updated_at), user_id, sqlc.arg(to_feed_id), category
+  FROM feed_follows
+  WHERE feed_id = sqlc.arg(from_feed_id)
+ON CONFLICT (user_id, feed_id) DO NOTHING;
@@ -5,7 +5,8 @@ INSERT INTO feeds(
   updated_at,
   name,
   url,
-  user_id
+  user_id,
+  site_url
 )
 VALUES (
   $1,
@@ -13,7 +14,8 @@ VALUES (
   $3,
   $4,
   $5,
-  $6
+  $6,
+  $7
 )
 RETURNING *;
 
@@ -28,10 +30,65 @@ SELECT *
 
 -- name: MarkFeedFetched :exec
 UPDATE feeds
-  SET last_fetched_at = $2, updated_at = $3
+  SET last_fetched_at = $2,
+    updated_at = $3,
+    etag = $4,
+    last_modified = $5,
+    next_fetch_at = $6,
+    fetch_interval_seconds = $7,
+    site_url = $8,
+    ttl_seconds = $9,
+    update_period_seconds = $10,
+    skip_hours = $11,
+    skip_days = $12,
+    consecutive_errors = 0,
+    locked_by = '',
+    locked_until = NULL
   WHERE id = $1;
 
--- name: GetNextFeedToFetch :one
+-- name: MarkFeedFailed :exec
+UPDATE feeds
+  SET updated_at = $2,
+    consecutive_errors = $3,
+    last_error = $4,
+    last_error_at = $5,
+    next_fetch_at = $6,
+    disabled = $7,
+    locked_by = '',
+    locked_until = NULL
+  WHERE id = $1;
+
+-- name: GetDisabledFeeds :many
 SELECT *
   FROM feeds
-  ORDER BY last_fetched_at ASC NULLS FIRST LIMIT 1;
+  WHERE disabled = TRUE
+  ORDER BY last_error_at DESC;
+
+-- name: EnableFeed :exec
+UPDATE feeds
+  SET disabled = FALSE, consecutive_errors = 0, next_fetch_at = NULL, updated_at = $2
+  WHERE id = $1;
+
+-- name: ClaimNextFeedToFetch :one
+UPDATE feeds
+  SET locked_by = sqlc.arg(worker_id), locked_until = now() + make_interval(secs => sqlc.arg(lease_seconds)::double precision)
+  WHERE id = (
+    SELECT id
+      FROM feeds
+      WHERE disabled = FALSE
+        AND (next_fetch_at IS NULL OR next_fetch_at <= now())
+        AND (locked_until IS NULL OR locked_until < now())
+      ORDER BY next_fetch_at ASC NULLS FIRST
+      LIMIT 1
+      FOR UPDATE SKIP LOCKED
+  )
+  RETURNING *;
+
+-- name: UpdateFeedURL :exec
+UPDATE feeds
+  SET url = $2, updated_at = $3
+  WHERE id = $1;
+
+-- name: DeleteFeed :exec
+DELETE FROM feeds
+  WHERE id = $1;
@@ -0,0 +1,34 @@
+-- name: CreateFetchLog :exec
+INSERT INTO fetch_log (
+  id,
+  feed_id,
+  started_at,
+  finished_at,
+  http_status,
+  bytes,
+  item_count,
+  new_post_count,
+  error
+)
+VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9
+);
+
+-- name: GetFetchLogForFeed :many
+SELECT *
+  FROM fetch_log
+  WHERE feed_id = $1
+  ORDER BY started_at DESC
+  LIMIT $2;
+
+-- name: DeleteFetchLogBefore :execrows
+DELETE FROM fetch_log
+  WHERE started_at < $1;
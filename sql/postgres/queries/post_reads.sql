@@ -0,0 +1,39 @@
+-- name: MarkPostRead :execrows
+INSERT INTO post_reads (user_id, post_id, read_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, post_id) DO NOTHING;
+
+-- name: MarkFeedPostsRead :execrows
+INSERT INTO post_reads (user_id, post_id, read_at)
+SELECT sqlc.arg(user_id), id, sqlc.arg(read_at)
+  FROM posts
+  WHERE feed_id = sqlc.arg(feed_id)
+ON CONFLICT (user_id, post_id) DO NOTHING;
+
+-- name: MarkAllPostsRead :execrows
+INSERT INTO post_reads (user_id, post_id, read_at)
+SELECT sqlc.arg(user_id), id, sqlc.arg(read_at)
+  FROM posts
+  WHERE feed_id IN (
+    SELECT feed_id
+      FROM feed_follows
+      WHERE user_id = sqlc.arg(user_id)
+  )
+ON CONFLICT (user_id, post_id) DO NOTHING;
+
+-- name: MarkPostUnread :execrows
+DELETE FROM post_reads
+  WHERE user_id = $1 AND post_id = $2;
+
+-- name: MarkFeedPostsUnread :execrows
+DELETE FROM post_reads
+  WHERE user_id = $1
+    AND post_id IN (
+      SELECT id
+        FROM posts
+        WHERE feed_id = $2
+    );
+
+-- name: MarkAllPostsUnread :execrows
+DELETE FROM post_reads
+  WHERE user_id = $1;
@@ -0,0 +1,25 @@
+-- name: CreatePostRevision :exec
+INSERT INTO post_revisions (
+  id,
+  created_at,
+  post_id,
+  title,
+  url,
+  description,
+  content_hash
+)
+VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7
+);
+
+-- name: GetPostRevisions :many
+SELECT *
+  FROM post_revisions
+  WHERE post_id = $1
+  ORDER BY created_at DESC;
@@ -0,0 +1,49 @@
+-- name: StarPost :one
+INSERT INTO post_stars (
+  user_id,
+  post_id,
+  note,
+  tags,
+  created_at,
+  updated_at
+)
+VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6
+)
+ON CONFLICT (user_id, post_id) DO UPDATE
+  SET note = EXCLUDED.note, tags = EXCLUDED.tags, updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: UnstarPost :execrows
+DELETE FROM post_stars
+  WHERE user_id = $1 AND post_id = $2;
+
+-- name: GetStarredPostsForUser :many
+SELECT posts.id, posts.title, posts.url, posts.published_at, post_stars.note, post_stars.tags, post_stars.created_at AS starred_at
+  FROM post_stars
+  INNER JOIN posts ON posts.id = post_stars.post_id
+  WHERE post_stars.user_id = sqlc.arg(user_id)
+    AND (sqlc.arg(tag)::text = '' OR sqlc.arg(tag)::text = ANY(post_stars.tags))
+  ORDER BY post_stars.created_at DESC;
+
+-- name: MovePostStars :exec
+INSERT INTO post_stars (
+  user_id,
+  post_id,
+  note,
+  tags,
+  created_at,
+  updated_at
+)
+SELECT post_stars.user_id, target.id, post_stars.note, post_stars.tags, post_stars.created_at, sqlc.arg(updated_at)
+  FROM post_stars
+  INNER JOIN posts AS source ON source.id = post_stars.post_id
+  INNER JOIN posts AS target ON target.guid = source.guid
+  WHERE source.feed_id = sqlc.arg(from_feed_id)
+    AND target.feed_id = sqlc.arg(to_feed_id)
+ON CONFLICT (user_id, post_id) DO NOTHING;
@@ -1,4 +1,4 @@
--- name: CreatePost :one
+-- name: CreatePost :exec
 INSERT INTO posts (
   id,
   created_at,
@@ -7,7 +7,10 @@ INSERT INTO posts (
   url,
   description,
   published_at,
-  feed_id
+  feed_id,
+  published_at_source,
+  guid,
+  content_hash
 )
 VALUES (
   $1,
@@ -17,17 +20,92 @@ VALUES (
   $5,
   $6,
   $7,
-  $8
-)
-RETURNING *;
+  $8,
+  $9,
+  $10,
+  $11
+);
+
+-- name: GetPostByGUID :one
+SELECT id, created_at, updated_at, title, url, description, published_at, feed_id, published_at_source, guid, content_hash
+  FROM posts
+  WHERE feed_id = $1 AND guid = $2;
+
+-- name: GetPostByID :one
+SELECT id, created_at, updated_at, title, url, description, published_at, feed_id, published_at_source, guid, content_hash
+  FROM posts
+  WHERE id = $1;
+
+-- name: GetPostByURL :one
+SELECT id, created_at, updated_at, title, url, description, published_at, feed_id, published_at_source, guid, content_hash
+  FROM posts
+  WHERE feed_id = $1 AND url = $2 AND guid = url;
+
+-- name: UpdatePost :exec
+UPDATE posts
+  SET updated_at = $2, title = $3, url = $4, description = $5, content_hash = $6
+  WHERE id = $1;
+
+-- name: UpdatePostGUID :exec
+UPDATE posts
+  SET updated_at = $2, guid = $3
+  WHERE id = $1;
+
+-- name: MovePosts :exec
+UPDATE posts
+  SET feed_id = sqlc.arg(to_feed_id), updated_at = sqlc.arg(updated_at)
+  WHERE feed_id = sqlc.arg(from_feed_id)
+    AND guid NOT IN (
+      SELECT guid
+        FROM posts
+        WHERE feed_id = sqlc.arg(to_feed_id)
+    );
 
 -- name: GetPostsForUser :many
-SELECT title, url, published_at
+SELECT posts.id, posts.title, posts.url, posts.published_at, feeds.name AS feed_name, post_reads.read_at, post_stars.created_at AS starred_at
+  FROM posts
+  INNER JOIN feeds ON feeds.id = posts.feed_id
+  INNER JOIN feed_follows ON feed_follows.feed_id = posts.feed_id AND feed_follows.user_id = sqlc.arg(user_id)
+  LEFT JOIN post_reads ON post_reads.post_id = posts.id AND post_reads.user_id = sqlc.arg(user_id)
+  LEFT JOIN post_stars ON post_stars.post_id = posts.id AND post_stars.user_id = sqlc.arg(user_id)
+  WHERE (sqlc.arg(include_read)::boolean OR post_reads.post_id IS NULL)
+    AND (sqlc.narg(feed_id)::uuid IS NULL OR posts.feed_id = sqlc.narg(feed_id))
+    AND (
+      sqlc.arg(category)::text = ''
+      OR feed_follows.category = sqlc.arg(category)
+      OR feed_follows.category LIKE sqlc.arg(category) || '/%'
+    )
+    AND (sqlc.narg(since)::timestamp IS NULL OR posts.published_at >= sqlc.narg(since))
+    AND (sqlc.narg(until)::timestamp IS NULL OR posts.published_at < sqlc.narg(until))
+    AND (
+      sqlc.narg(after_published_at)::timestamp IS NULL
+      OR (sqlc.arg(oldest_first)::boolean AND (posts.published_at, posts.id) > (sqlc.narg(after_published_at), sqlc.narg(after_id)::uuid))
+      OR (NOT sqlc.arg(oldest_first)::boolean AND (posts.published_at, posts.id) < (sqlc.narg(after_published_at), sqlc.narg(after_id)::uuid))
+    )
+  ORDER BY
+    CASE WHEN sqlc.arg(oldest_first)::boolean THEN posts.published_at END ASC,
+    CASE WHEN sqlc.arg(oldest_first)::boolean THEN posts.id END ASC,
+    posts.published_at DESC,
+    posts.id DESC
+  LIMIT sqlc.arg(max_posts);
+
+-- name: SearchPostsForUser :many
+SELECT posts.id, posts.title, posts.url, posts.published_at, feeds.name AS feed_name,
+    ts_rank(posts.search_vector, query)::real AS rank,
+    ts_headline(
+      'english',
+      regexp_replace(posts.description, '<[^>]*>', ' ', 'g'),
+      query,
+      'StartSel=*, StopSel=*, MaxWords=35, MinWords=15, MaxFragments=2'
+    )::text AS snippet
   FROM posts
-  WHERE feed_id IN (
-    SELECT feed_id
-      FROM feed_follows
-      WHERE user_id = $1
-  )
-  ORDER BY published_at DESC
-  LIMIT $2;
+  INNER JOIN feeds ON feeds.id = posts.feed_id
+  CROSS JOIN websearch_to_tsquery('english', sqlc.arg(query)) AS query
+  WHERE posts.search_vector @@ query
+    AND posts.feed_id IN (
+      SELECT feed_id
+        FROM feed_follows
+        WHERE user_id = sqlc.arg(user_id)
+    )
+  ORDER BY rank DESC, posts.published_at DESC
+  LIMIT sqlc.arg(max_results);
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE posts ADD COLUMN published_at_source varchar(10) NOT NULL DEFAULT 'item';
+
+-- +goose Down
+ALTER TABLE posts DROP COLUMN published_at_source;
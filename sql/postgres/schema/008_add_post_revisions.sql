@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE posts ADD COLUMN content_hash varchar(64) NOT NULL DEFAULT '';
+
+CREATE TABLE post_revisions (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  post_id UUID NOT NULL,
+  title TEXT NOT NULL,
+  url varchar(255) NOT NULL,
+  description TEXT NOT NULL,
+  content_hash varchar(64) NOT NULL,
+  FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE post_revisions;
+ALTER TABLE posts DROP COLUMN content_hash;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE feeds ADD COLUMN locked_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE feeds ADD COLUMN locked_until TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE feeds DROP COLUMN locked_until;
+ALTER TABLE feeds DROP COLUMN locked_by;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE fetch_log (
+  id UUID PRIMARY KEY,
+  feed_id UUID NOT NULL,
+  started_at TIMESTAMP NOT NULL,
+  finished_at TIMESTAMP NOT NULL,
+  http_status INTEGER NOT NULL,
+  bytes BIGINT NOT NULL,
+  item_count INTEGER NOT NULL,
+  new_post_count INTEGER NOT NULL,
+  error TEXT NOT NULL DEFAULT '',
+  FOREIGN KEY (feed_id) REFERENCES feeds(id) ON DELETE CASCADE
+);
+
+CREATE INDEX fetch_log_feed_id_started_at_idx ON fetch_log (feed_id, started_at);
+
+-- +goose Down
+DROP TABLE fetch_log;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE feeds ALTER COLUMN locked_until TYPE TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE feeds ALTER COLUMN locked_until TYPE TIMESTAMP;
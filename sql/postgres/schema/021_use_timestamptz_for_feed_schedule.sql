@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE feeds ALTER COLUMN next_fetch_at TYPE TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE feeds ALTER COLUMN next_fetch_at TYPE TIMESTAMP;